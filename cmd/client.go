@@ -1,16 +1,24 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
+	"github.com/golgoth31/aliasme/internal/auth"
 	aliasme "github.com/golgoth31/aliasme/pkg/proto"
 )
 
@@ -30,6 +38,7 @@ var createAliasCmd = &cobra.Command{
 		conn, err := grpc.NewClient(
 			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
 		)
 		if err != nil {
 			return fmt.Errorf("failed to connect to gRPC server: %w", err)
@@ -51,6 +60,88 @@ var createAliasCmd = &cobra.Command{
 	},
 }
 
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in and save the session for the other client commands",
+	Long: `Log in by approving the login in a browser, or with a password with --password.
+The tokens are saved to the token file and used by the other client commands.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := tokenFilePath()
+		if err != nil {
+			return err
+		}
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewAuthServiceClient(conn)
+		var resp *aliasme.TokenResponse
+		if viper.GetBool("login.password") {
+			resp, err = passwordLogin(client)
+		} else {
+			resp, err = deviceLogin(client)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := saveTokens(path, resp); err != nil {
+			return err
+		}
+
+		fmt.Printf("Logged in, session saved to %s (expires %s without use)\n", path, resp.RefreshTokenExpiresAt.AsTime().Local().Format(time.RFC1123))
+		return nil
+	},
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "End the session saved by the login command",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := tokenFilePath()
+		if err != nil {
+			return err
+		}
+		tokens, err := loadTokens(path)
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Println("Not logged in")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewAuthServiceClient(conn)
+		if _, err := client.Logout(ctx, &aliasme.LogoutRequest{RefreshToken: tokens.RefreshToken}); err != nil {
+			return fmt.Errorf("failed to log out: %w", err)
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove token file: %w", err)
+		}
+
+		fmt.Println("Logged out")
+		return nil
+	},
+}
+
 var listAliasesCmd = &cobra.Command{
 	Use:   "list-aliases",
 	Short: "List all aliases for a user",
@@ -61,6 +152,7 @@ var listAliasesCmd = &cobra.Command{
 		conn, err := grpc.NewClient(
 			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
 		)
 		if err != nil {
 			return fmt.Errorf("failed to connect to gRPC server: %w", err)
@@ -92,6 +184,7 @@ var listUsersCmd = &cobra.Command{
 		client, err := grpc.NewClient(
 			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
 		)
 		if err != nil {
 			return fmt.Errorf("failed to connect to gRPC server: %w", err)
@@ -117,18 +210,325 @@ var listUsersCmd = &cobra.Command{
 	},
 }
 
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all data stored about a user",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var format aliasme.ExportFormat
+		switch viper.GetString("export.format") {
+		case "json":
+			format = aliasme.ExportFormat_EXPORT_FORMAT_JSON
+		case "zip":
+			format = aliasme.ExportFormat_EXPORT_FORMAT_ZIP
+		default:
+			return fmt.Errorf("unsupported export format %q, expected json or zip", viper.GetString("export.format"))
+		}
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewUserServiceClient(conn)
+		stream, err := client.ExportUserData(ctx, &aliasme.ExportUserDataRequest{
+			UserId: viper.GetString("alias.user_id"),
+			Format: format,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export user data: %w", err)
+		}
+
+		var out *os.File
+		defer func() {
+			if out != nil && out != os.Stdout {
+				out.Close()
+			}
+		}()
+
+		for {
+			chunk, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to export user data: %w", err)
+			}
+
+			if out == nil {
+				out, err = openExportOutput(viper.GetString("export.output"), chunk.Filename)
+				if err != nil {
+					return err
+				}
+			}
+			if _, err := out.Write(chunk.Data); err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+
+		if out != nil && out != os.Stdout {
+			if err := out.Close(); err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+			fmt.Printf("Exported user data to %s\n", out.Name())
+			out = nil
+		}
+		return nil
+	},
+}
+
+var verifyEmailCmd = &cobra.Command{
+	Use:   "verify-email",
+	Short: "Verify an email address with the code sent to it",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		emailID := viper.GetString("verify.email_id")
+		if emailID == "" {
+			return errors.New("--email-id is required")
+		}
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewEmailServiceClient(conn)
+
+		if viper.GetBool("verify.send_code") {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.ResendVerification(ctx, &aliasme.ResendVerificationRequest{
+				EmailId:            emailID,
+				VerificationMethod: aliasme.VerificationMethod_VERIFICATION_METHOD_CODE,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to send verification code: %w", err)
+			}
+			fmt.Printf("Verification code sent, valid until %s\n", resp.ExpiresAt.AsTime().Local().Format(time.RFC1123))
+		}
+
+		code := viper.GetString("verify.code")
+		if code == "" {
+			code, err = promptLine("Verification code: ")
+			if err != nil {
+				return err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := client.VerifyEmail(ctx, &aliasme.VerifyEmailRequest{
+			EmailId: emailID,
+			Code:    code,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to verify email: %w", err)
+		}
+
+		fmt.Printf("Successfully verified email: %s\n", resp.Address)
+		return nil
+	},
+}
+
+// promptLine prints the prompt and reads a line from stdin
+func promptLine(prompt string) (string, error) {
+	fmt.Print(prompt)
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+// bearerCredentials attaches an access token to every RPC
+type bearerCredentials string
+
+// GetRequestMetadata returns the authorization metadata, if a token is set
+func (c bearerCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	if c == "" {
+		return nil, nil
+	}
+
+	return map[string]string{"authorization": "Bearer " + string(c)}, nil
+}
+
+// RequireTransportSecurity allows tokens over the plaintext local connection
+func (c bearerCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// deviceLogin starts a device login and polls it while the user approves it
+// in a browser
+func deviceLogin(client aliasme.AuthServiceClient) (*aliasme.TokenResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	start, err := client.StartDeviceLogin(ctx, &aliasme.StartDeviceLoginRequest{})
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start login: %w", err)
+	}
+
+	fmt.Printf("Open %s and enter the code %s\n", start.VerificationUri, start.UserCode)
+	fmt.Printf("or open %s\n", start.VerificationUriComplete)
+	fmt.Println("Waiting for the login to be approved...")
+
+	interval := max(time.Duration(start.Interval)*time.Second, time.Second)
+	for {
+		time.Sleep(interval)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		resp, err := client.PollDeviceLogin(ctx, &aliasme.PollDeviceLoginRequest{DeviceCode: start.DeviceCode})
+		cancel()
+		switch status.Code(err) {
+		case codes.OK:
+			return resp, nil
+		case codes.FailedPrecondition:
+			// Not approved yet
+		case codes.ResourceExhausted:
+			interval += 5 * time.Second
+		case codes.PermissionDenied:
+			return nil, errors.New("login denied")
+		case codes.DeadlineExceeded:
+			if !time.Now().Before(start.ExpiresAt.AsTime()) {
+				return nil, errors.New("login not approved in time, try again")
+			}
+			return nil, fmt.Errorf("failed to log in: %w", err)
+		default:
+			return nil, fmt.Errorf("failed to log in: %w", err)
+		}
+	}
+}
+
+// passwordLogin prompts for a password, and for a second factor when enabled,
+// and logs in
+func passwordLogin(client aliasme.AuthServiceClient) (*aliasme.TokenResponse, error) {
+	login := viper.GetString("login.login")
+	if login == "" {
+		var err error
+		if login, err = promptLine("Username or email: "); err != nil {
+			return nil, err
+		}
+	}
+	password, err := promptPassword("Password: ")
+	if err != nil {
+		return nil, err
+	}
+
+	req := &aliasme.LoginRequest{
+		Login:    login,
+		Password: password,
+	}
+	resp, err := loginRequest(client, req)
+	if errors.Is(err, auth.ErrPasskeyRequired) {
+		return nil, errors.New("this account logs in with a passkey, run login without --password to approve the login in a browser")
+	}
+	if status.Code(err) == codes.FailedPrecondition {
+		// Two-factor authentication is enabled
+		if req.TotpCode, err = promptLine("Two-factor or recovery code: "); err != nil {
+			return nil, err
+		}
+		resp, err = loginRequest(client, req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to log in: %w", err)
+	}
+
+	return resp, nil
+}
+
+// loginRequest calls Login with its own timeout, since the user may be
+// prompted between two attempts
+func loginRequest(client aliasme.AuthServiceClient, req *aliasme.LoginRequest) (*aliasme.TokenResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return client.Login(ctx, req)
+}
+
+// promptPassword reads a password from the terminal without echoing it
+func promptPassword(prompt string) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return promptLine(prompt)
+	}
+
+	fmt.Print(prompt)
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	return string(password), nil
+}
+
+// openExportOutput opens the export destination, "-" being stdout and an
+// empty path the file name suggested by the server
+func openExportOutput(path, filename string) (*os.File, error) {
+	if path == "-" {
+		return os.Stdout, nil
+	}
+	if path == "" {
+		path = filename
+	}
+
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	return out, nil
+}
+
 func init() {
 	rootCmd.AddCommand(clientCmd)
-	clientCmd.AddCommand(createAliasCmd, listAliasesCmd, listUsersCmd)
+	clientCmd.AddCommand(loginCmd, logoutCmd, createAliasCmd, listAliasesCmd, listUsersCmd, exportCmd, verifyEmailCmd)
 
 	// Common flags for all client commands
 	clientCmd.PersistentFlags().String("user-id", "", "User ID")
 	// clientCmd.MarkPersistentFlagRequired("user-id")
 
+	clientCmd.PersistentFlags().String("token", "", "Access token or personal access token (default: the session saved by the login command)")
+	clientCmd.PersistentFlags().String("token-file", "", "File the login command saves the session to (default: aliasme/token.json in the user config directory)")
+
 	if err := viper.BindPFlag("alias.user_id", clientCmd.PersistentFlags().Lookup("user-id")); err != nil {
 		fmt.Fprintf(os.Stderr, "Error binding user-id flag: %v\n", err)
 		os.Exit(1)
 	}
+	if err := viper.BindPFlag("client.token", clientCmd.PersistentFlags().Lookup("token")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding token flag: %v\n", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("client.token_file", clientCmd.PersistentFlags().Lookup("token-file")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding token-file flag: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Flags specific to login command
+	loginCmd.Flags().String("login", "", "Username or email, for password logins (prompted when empty)")
+	loginCmd.Flags().Bool("password", false, "Log in with a password instead of approving the login in a browser")
+
+	if err := viper.BindPFlag("login.login", loginCmd.Flags().Lookup("login")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding login flag: %v\n", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("login.password", loginCmd.Flags().Lookup("password")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding password flag: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Flags specific to create-alias command
 	createAliasCmd.Flags().String("email-id", "", "Email ID")
@@ -145,4 +545,35 @@ func init() {
 		fmt.Fprintf(os.Stderr, "Error binding source flag: %v\n", err)
 		os.Exit(1)
 	}
+
+	// Flags specific to export command
+	exportCmd.Flags().String("format", "json", "Export format (json or zip)")
+	exportCmd.Flags().StringP("output", "o", "", "Output file, - for stdout (default: name suggested by the server)")
+
+	if err := viper.BindPFlag("export.format", exportCmd.Flags().Lookup("format")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding format flag: %v\n", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("export.output", exportCmd.Flags().Lookup("output")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding output flag: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Flags specific to verify-email command
+	verifyEmailCmd.Flags().String("email-id", "", "Email ID")
+	verifyEmailCmd.Flags().String("code", "", "Verification code (prompted when empty)")
+	verifyEmailCmd.Flags().Bool("send-code", false, "Send a new verification code before prompting for it")
+
+	if err := viper.BindPFlag("verify.email_id", verifyEmailCmd.Flags().Lookup("email-id")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding email-id flag: %v\n", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("verify.code", verifyEmailCmd.Flags().Lookup("code")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding code flag: %v\n", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("verify.send_code", verifyEmailCmd.Flags().Lookup("send-code")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding send-code flag: %v\n", err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+)
+
+// refreshMargin is how long before it expires the stored access token is
+// refreshed
+const refreshMargin = 30 * time.Second
+
+// errLoginRequired is returned when the stored login cannot be used anymore
+var errLoginRequired = status.Error(codes.Unauthenticated, "login expired, run `aliasme client login` again")
+
+// clientCredentials returns the credentials of the client commands: the
+// token given on the command line, or else the one saved by the login
+// command
+func clientCredentials(token string) credentials.PerRPCCredentials {
+	if token != "" {
+		return bearerCredentials(token)
+	}
+
+	return &storedCredentials{}
+}
+
+// storedCredentials attaches the saved access token to every RPC, refreshing
+// it when it expires
+type storedCredentials struct {
+	mu     sync.Mutex
+	path   string
+	tokens *aliasme.TokenResponse
+}
+
+// GetRequestMetadata returns the authorization metadata, if the user logged
+// in
+func (c *storedCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tokens == nil {
+		path, err := tokenFilePath()
+		if err != nil {
+			return nil, err
+		}
+		tokens, err := loadTokens(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Not logged in, the server decides whether the RPC is public
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.path, c.tokens = path, tokens
+	}
+
+	if time.Until(c.tokens.AccessTokenExpiresAt.AsTime()) < refreshMargin {
+		if err := c.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return bearerCredentials(c.tokens.AccessToken).GetRequestMetadata(ctx, uri...)
+}
+
+// RequireTransportSecurity allows tokens over the plaintext local connection
+func (c *storedCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// refresh exchanges the refresh token for new tokens and saves them
+func (c *storedCredentials) refresh(ctx context.Context) error {
+	if !c.tokens.RefreshTokenExpiresAt.AsTime().After(time.Now()) {
+		return errLoginRequired
+	}
+
+	conn, err := grpc.NewClient(
+		fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to connect to gRPC server: %w", err)
+	}
+	defer conn.Close()
+
+	tokens, err := aliasme.NewAuthServiceClient(conn).Refresh(ctx, &aliasme.RefreshRequest{
+		RefreshToken: c.tokens.RefreshToken,
+	})
+	if status.Code(err) == codes.Unauthenticated {
+		return errLoginRequired
+	}
+	if err != nil {
+		return fmt.Errorf("failed to refresh access token: %w", err)
+	}
+
+	c.tokens = tokens
+	return saveTokens(c.path, tokens)
+}
+
+// tokenFilePath returns the path of the file the login command saves tokens
+// to
+func tokenFilePath() (string, error) {
+	if path := viper.GetString("client.token_file"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the token file, set client.token_file: %w", err)
+	}
+
+	return filepath.Join(dir, "aliasme", "token.json"), nil
+}
+
+// loadTokens reads the tokens saved by the login command
+func loadTokens(path string) (*aliasme.TokenResponse, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens aliasme.TokenResponse
+	if err := protojson.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("invalid token file %s: %w", path, err)
+	}
+
+	return &tokens, nil
+}
+
+// saveTokens writes the tokens to a file only the user can read, replacing
+// it atomically
+func saveTokens(path string, tokens *aliasme.TokenResponse) error {
+	data, err := protojson.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("failed to encode tokens: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create token directory: %w", err)
+	}
+
+	// CreateTemp creates the file with 0600 permissions
+	tmp, err := os.CreateTemp(dir, ".token-*")
+	if err != nil {
+		return fmt.Errorf("failed to save tokens: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save tokens: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save tokens: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save tokens: %w", err)
+	}
+
+	return nil
+}
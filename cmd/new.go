@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/clipboard"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// clipboardWriter receives the alias address when --copy is set
+var clipboardWriter clipboard.Writer = clipboard.System()
+
+var newCmd = &cobra.Command{
+	Use:   "new <hostname>",
+	Short: "Get or create the alias for a website",
+	Long:  `Return your alias for the given website, creating one with a prefix derived from the hostname if needed.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("new.token"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewEmailServiceClient(conn)
+		resp, err := client.QuickCreateAlias(ctx, &aliasme.QuickCreateAliasRequest{
+			UserId:   viper.GetString("new.user_id"),
+			EmailId:  viper.GetString("new.email_id"),
+			Hostname: args[0],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get alias: %w", err)
+		}
+
+		if resp.Created {
+			fmt.Printf("Created alias for %s: %s\n", resp.Alias.GetWebsite(), resp.Alias.GetAliasAddress())
+		} else {
+			fmt.Printf("Existing alias for %s: %s\n", resp.Alias.GetWebsite(), resp.Alias.GetAliasAddress())
+		}
+
+		if viper.GetBool("new.copy") {
+			if err := clipboardWriter.WriteText(resp.Alias.GetAliasAddress()); err != nil {
+				return fmt.Errorf("failed to copy alias to clipboard: %w", err)
+			}
+			fmt.Println("Copied to clipboard")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(newCmd)
+
+	newCmd.Flags().String("user-id", "", "User ID")
+	newCmd.Flags().String("email-id", "", "Destination email ID (default: first verified email)")
+	newCmd.Flags().Bool("copy", false, "Copy the alias address to the clipboard")
+	newCmd.Flags().String("token", "", "Access token or personal access token (default: the session saved by the login command)")
+
+	if err := viper.BindPFlag("new.user_id", newCmd.Flags().Lookup("user-id")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding user-id flag: %v\n", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("new.email_id", newCmd.Flags().Lookup("email-id")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding email-id flag: %v\n", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("new.copy", newCmd.Flags().Lookup("copy")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding copy flag: %v\n", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("new.token", newCmd.Flags().Lookup("token")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding token flag: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golgoth31/aliasme/internal/database"
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+var setRoleCmd = &cobra.Command{
+	Use:   "set-role <username-or-email> <user|admin>",
+	Short: "Set the role of a user",
+	Long:  `Set the role of a user directly in the database, e.g. to create the first admin.`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		login, role := args[0], args[1]
+		if role != models.RoleUser && role != models.RoleAdmin {
+			return fmt.Errorf("unknown role %q, expected %s or %s", role, models.RoleUser, models.RoleAdmin)
+		}
+
+		db, err := database.New(&database.Config{
+			Path: viper.GetString("database.path"),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to initialize database: %w", err)
+		}
+
+		var user models.User
+		if err := db.First(&user, "username = ? OR email = ?", login, login).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("user %q not found", login)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		if err := db.Model(&user).Update("role", role).Error; err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		fmt.Printf("User %s (%s) is now %s\n", user.Username, user.ID, role)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(setRoleCmd)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/golgoth31/aliasme/internal/logger"
 	"github.com/spf13/cobra"
@@ -53,8 +54,11 @@ func initConfig() {
 		viper.AddConfigPath("./config")
 	}
 
+	// Settings are overridden by ALIASME_<SECTION>_<KEY> variables, e.g.
+	// ALIASME_HTTP_BASE_URL
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("ALIASME")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
 	// Logging configuration
 	viper.SetDefault("logging.format", "console")
@@ -70,13 +74,91 @@ func initConfig() {
 	viper.SetDefault("ovh.application_secret", "")
 	viper.SetDefault("ovh.consumer_key", "")
 
+	// HTTP configuration
+	viper.SetDefault("http.base_url", "http://localhost:8080")
+
+	// Security configuration
+	viper.SetDefault("security.secret", "")
+
+	// Authentication configuration
+	viper.SetDefault("auth.access_token_ttl", "15m")
+	viper.SetDefault("auth.refresh_token_ttl", "720h")
+	viper.SetDefault("auth.totp_issuer", "AliasMe")
+	viper.SetDefault("auth.totp_max_failures", 5)
+	viper.SetDefault("auth.totp_lockout", "5m")
+	viper.SetDefault("auth.device_code_ttl", "10m")
+	viper.SetDefault("auth.device_poll_interval", "5s")
+
+	// WebAuthn configuration, the relying party defaulting to http.base_url
+	viper.SetDefault("webauthn.rp_id", "")
+	viper.SetDefault("webauthn.rp_display_name", "AliasMe")
+	viper.SetDefault("webauthn.origins", []string{})
+	viper.SetDefault("webauthn.challenge_ttl", "5m")
+
+	// OpenID Connect single sign-on, disabled without an issuer
+	viper.SetDefault("oidc.issuer", "")
+	viper.SetDefault("oidc.client_id", "")
+	viper.SetDefault("oidc.client_secret", "")
+	viper.SetDefault("oidc.redirect_url", "")
+	viper.SetDefault("oidc.scopes", []string{"email", "profile"})
+	viper.SetDefault("oidc.groups_claim", "groups")
+	viper.SetDefault("oidc.role_groups", map[string][]string{})
+
+	// Password configuration
+	viper.SetDefault("password.min_length", 12)
+	viper.SetDefault("password.require_lowercase", false)
+	viper.SetDefault("password.require_uppercase", false)
+	viper.SetDefault("password.require_digit", false)
+	viper.SetDefault("password.require_symbol", false)
+	viper.SetDefault("password.reset_token_ttl", "1h")
+	viper.SetDefault("password.reset_interval", "1m")
+
 	// SMTP configuration
+	viper.SetDefault("smtp.transport", "smtp")
 	viper.SetDefault("smtp.host", "")
 	viper.SetDefault("smtp.port", "")
 	viper.SetDefault("smtp.username", "")
 	viper.SetDefault("smtp.password", "")
+	viper.SetDefault("smtp.tls", "starttls")
+	viper.SetDefault("smtp.sendmail_path", "/usr/sbin/sendmail")
+	viper.SetDefault("smtp.dir", "mail")
 	viper.SetDefault("smtp.from_email", "")
 
+	// Verification configuration
+	viper.SetDefault("verification.token_ttl", "24h")
+	viper.SetDefault("verification.resend_interval", "1m")
+	viper.SetDefault("verification.code_ttl", "15m")
+	viper.SetDefault("verification.max_code_attempts", 5)
+
+	// Mail queue configuration
+	viper.SetDefault("queue.poll_interval", "10s")
+	viper.SetDefault("queue.max_attempts", 8)
+	viper.SetDefault("queue.backoff", "30s")
+	viper.SetDefault("queue.max_backoff", "1h")
+	viper.SetDefault("queue.retention", "168h")
+
+	// Email templates configuration
+	viper.SetDefault("templates.dir", "")
+	viper.SetDefault("templates.default_locale", "en")
+
+	// Alias configuration
+	viper.SetDefault("alias.quarantine_period", "2160h")
+	viper.SetDefault("alias.domains", []string{"yourdomain.com"})
+
+	// Notifications configuration
+	viper.SetDefault("notifications.digest_interval", "24h")
+	viper.SetDefault("notifications.expiry_notice", "72h")
+
+	// Account configuration
+	viper.SetDefault("account.deletion_grace_period", "720h")
+
+	// Trash configuration
+	viper.SetDefault("trash.retention", "720h")
+	viper.SetDefault("trash.purge_interval", "1h")
+
+	// Plans configuration
+	viper.SetDefault("plans.default", "")
+
 	if err := viper.ReadInConfig(); err != nil {
 		fmt.Println("Using config file:", viper.ConfigFileUsed())
 		fmt.Println(err.Error())
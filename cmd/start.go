@@ -5,13 +5,23 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"time"
 
+	"github.com/golgoth31/aliasme/internal/admin"
+	"github.com/golgoth31/aliasme/internal/auth"
 	"github.com/golgoth31/aliasme/internal/database"
 	"github.com/golgoth31/aliasme/internal/email"
 	"github.com/golgoth31/aliasme/internal/logger"
 	"github.com/golgoth31/aliasme/internal/ovh"
+	"github.com/golgoth31/aliasme/internal/passkey"
+	"github.com/golgoth31/aliasme/internal/plan"
+	"github.com/golgoth31/aliasme/internal/sso"
+	"github.com/golgoth31/aliasme/internal/trash"
 	"github.com/golgoth31/aliasme/internal/user"
+	"github.com/golgoth31/aliasme/internal/utils"
+	"github.com/golgoth31/aliasme/internal/validation"
+	"github.com/golgoth31/aliasme/internal/web"
 	aliasme "github.com/golgoth31/aliasme/pkg/proto"
 	"github.com/golgoth31/aliasme/pkg/static"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -58,27 +68,154 @@ func startServer() error {
 		log.Error().Err(err).Msg("Failed to initialize OVH client")
 	}
 
+	// Validate the public URL used in links
+	baseURL, err := utils.ParseBaseURL(baseURLSetting())
+	if err != nil {
+		return fmt.Errorf("invalid http.base_url: %w", err)
+	}
+
+	// The server secret keys the hashes of tokens stored in the database
+	secret := viper.GetString("security.secret")
+	if len(secret) < utils.MinSecretLength {
+		return fmt.Errorf("security.secret must be at least %d characters long", utils.MinSecretLength)
+	}
+
+	// Initialize mailer
+	mailer, err := email.NewMailer(email.MailerConfig{
+		Transport:    viper.GetString("smtp.transport"),
+		Host:         viper.GetString("smtp.host"),
+		Port:         viper.GetString("smtp.port"),
+		Username:     viper.GetString("smtp.username"),
+		Password:     viper.GetString("smtp.password"),
+		TLS:          viper.GetString("smtp.tls"),
+		SendmailPath: viper.GetString("smtp.sendmail_path"),
+		Dir:          viper.GetString("smtp.dir"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize mailer: %w", err)
+	}
+
 	// Initialize email service
 	emailService := email.New(db, email.Config{
-		SMTPHost:     viper.GetString("smtp.host"),
-		SMTPPort:     viper.GetString("smtp.port"),
-		SMTPUsername: viper.GetString("smtp.username"),
-		SMTPPassword: viper.GetString("smtp.password"),
-		FromEmail:    viper.GetString("smtp.from_email"),
-	})
+		FromEmail:       viper.GetString("smtp.from_email"),
+		BaseURL:         baseURL,
+		Secret:          []byte(secret),
+		TokenTTL:        viper.GetDuration("verification.token_ttl"),
+		CodeTTL:         viper.GetDuration("verification.code_ttl"),
+		MaxCodeAttempts: viper.GetInt("verification.max_code_attempts"),
+		ResendInterval:  viper.GetDuration("verification.resend_interval"),
+		TemplatesDir:    viper.GetString("templates.dir"),
+		DefaultLocale:   viper.GetString("templates.default_locale"),
+		Queue: email.QueueConfig{
+			PollInterval: viper.GetDuration("queue.poll_interval"),
+			MaxAttempts:  viper.GetInt("queue.max_attempts"),
+			Backoff:      viper.GetDuration("queue.backoff"),
+			MaxBackoff:   viper.GetDuration("queue.max_backoff"),
+			Retention:    viper.GetDuration("queue.retention"),
+		},
+	}, mailer)
 
-	// Initialize user service
-	userService := user.New(db)
+	// Initialize plan catalog
+	var planConfig plan.Config
+	if err := viper.UnmarshalKey("plans", &planConfig); err != nil {
+		return fmt.Errorf("failed to read plans configuration: %w", err)
+	}
+	plans := plan.NewCatalog(planConfig)
+
+	// Password policy of new passwords
+	passwordPolicy := auth.PasswordPolicy{
+		MinLength:        viper.GetInt("password.min_length"),
+		RequireLowercase: viper.GetBool("password.require_lowercase"),
+		RequireUppercase: viper.GetBool("password.require_uppercase"),
+		RequireDigit:     viper.GetBool("password.require_digit"),
+		RequireSymbol:    viper.GetBool("password.require_symbol"),
+	}
 
 	// Initialize email service implementation
-	emailServiceImpl := email.NewEmailService(db, ovhClient, emailService)
+	emailServiceImpl := email.NewEmailService(db, ovhClient, emailService, email.AliasConfig{
+		QuarantinePeriod: viper.GetDuration("alias.quarantine_period"),
+		Domains:          viper.GetStringSlice("alias.domains"),
+		TrashRetention:   viper.GetDuration("trash.retention"),
+		ExpiryNotice:     viper.GetDuration("notifications.expiry_notice"),
+	}, plans)
+
+	// Initialize user service
+	userService := user.New(db, plans, user.Config{
+		DeletionGracePeriod: viper.GetDuration("account.deletion_grace_period"),
+		Password:            passwordPolicy,
+		Secret:              []byte(secret),
+	}, emailServiceImpl, emailService)
+
+	// Initialize admin service
+	adminService := admin.New(db)
+
+	// Initialize auth service
+	authService := auth.New(db, auth.Config{
+		Secret:                []byte(secret),
+		AccessTokenTTL:        viper.GetDuration("auth.access_token_ttl"),
+		RefreshTokenTTL:       viper.GetDuration("auth.refresh_token_ttl"),
+		Password:              passwordPolicy,
+		ResetTokenTTL:         viper.GetDuration("password.reset_token_ttl"),
+		ResetInterval:         viper.GetDuration("password.reset_interval"),
+		TOTPIssuer:            viper.GetString("auth.totp_issuer"),
+		TOTPMaxFailures:       viper.GetInt("auth.totp_max_failures"),
+		TOTPLockout:           viper.GetDuration("auth.totp_lockout"),
+		DeviceVerificationURL: baseURL + "/device",
+		DeviceCodeTTL:         viper.GetDuration("auth.device_code_ttl"),
+		DevicePollInterval:    viper.GetDuration("auth.device_poll_interval"),
+	}, emailService)
 
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	// Initialize passkey service
+	passkeyService, err := passkey.New(db, passkey.Config{
+		BaseURL:       baseURL,
+		RPID:          viper.GetString("webauthn.rp_id"),
+		RPDisplayName: viper.GetString("webauthn.rp_display_name"),
+		Origins:       viper.GetStringSlice("webauthn.origins"),
+		ChallengeTTL:  viper.GetDuration("webauthn.challenge_ttl"),
+	}, authService)
+	if err != nil {
+		return fmt.Errorf("invalid webauthn configuration: %w", err)
+	}
+
+	// Initialize single sign-on, when an identity provider is configured
+	var ssoService *sso.Service
+	if issuer := viper.GetString("oidc.issuer"); issuer != "" {
+		redirectURL := viper.GetString("oidc.redirect_url")
+		if redirectURL == "" {
+			redirectURL = baseURL + "/auth/oidc/callback"
+		}
+		ssoService = sso.New(db, sso.Config{
+			Issuer:       issuer,
+			ClientID:     viper.GetString("oidc.client_id"),
+			ClientSecret: viper.GetString("oidc.client_secret"),
+			RedirectURL:  redirectURL,
+			Scopes:       viper.GetStringSlice("oidc.scopes"),
+			GroupsClaim:  viper.GetString("oidc.groups_claim"),
+			RoleGroups:   viper.GetStringMapStringSlice("oidc.role_groups"),
+		}, authService)
+	}
+
+	// Run background jobs: mail delivery, notifications, trash purge and
+	// account erasure
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go emailService.RunQueue(jobsCtx)
+	go emailServiceImpl.RunNotifier(jobsCtx, viper.GetDuration("notifications.digest_interval"))
+	go trash.RunPurger(jobsCtx, db, viper.GetDuration("trash.retention"), viper.GetDuration("trash.purge_interval"))
+	go userService.RunDeletions(jobsCtx, viper.GetDuration("trash.purge_interval"))
+
+	// Create gRPC server, authenticating every call, then validating its
+	// request
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authService.UnaryInterceptor(), validation.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(authService.StreamInterceptor(), validation.StreamInterceptor()),
+	)
 
 	// Register services
 	aliasme.RegisterUserServiceServer(grpcServer, userService)
 	aliasme.RegisterEmailServiceServer(grpcServer, emailServiceImpl)
+	aliasme.RegisterAdminServiceServer(grpcServer, adminService)
+	aliasme.RegisterAuthServiceServer(grpcServer, authService)
 
 	// Start gRPC server
 	go func() {
@@ -107,6 +244,12 @@ func startServer() error {
 	if err := aliasme.RegisterEmailServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
 		return fmt.Errorf("failed to register email service handler: %w", err)
 	}
+	if err := aliasme.RegisterAdminServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+		return fmt.Errorf("failed to register admin service handler: %w", err)
+	}
+	if err := aliasme.RegisterAuthServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+		return fmt.Errorf("failed to register auth service handler: %w", err)
+	}
 
 	// Create Echo instance
 	e := echo.New()
@@ -124,12 +267,38 @@ func startServer() error {
 	e.Use(echoprometheus.NewMiddleware("aliasme")) // adds middleware to gather metrics
 	e.GET("/metrics", echoprometheus.NewHandler()) // adds route to serve gathered metrics
 
+	// WebAuthn ceremonies, served next to the gateway
+	e.POST("/api/v1/webauthn/register/begin", passkeyService.BeginRegistration, passkeyService.RequireSession)
+	e.POST("/api/v1/webauthn/register/finish", passkeyService.FinishRegistration, passkeyService.RequireSession)
+	e.GET("/api/v1/webauthn/credentials", passkeyService.ListCredentials, passkeyService.RequireSession)
+	e.DELETE("/api/v1/webauthn/credentials/:id", passkeyService.DeleteCredential, passkeyService.RequireSession)
+	e.POST("/api/v1/webauthn/login/begin", passkeyService.BeginLogin)
+	e.POST("/api/v1/webauthn/login/finish", passkeyService.FinishLogin)
+
 	// Add gRPC-Gateway handler
 	e.Any("/api/*", func(c echo.Context) error {
 		mux.ServeHTTP(c.Response().Writer, c.Request())
 		return nil
 	})
 
+	// Landing page of the verification links
+	e.GET("/verify", web.VerifyHandler(emailServiceImpl))
+	e.GET("/reset-password", web.ResetFormHandler())
+	e.POST("/reset-password", web.ResetHandler(authService))
+	e.GET("/restore-account", web.RestoreFormHandler())
+	e.POST("/restore-account", web.RestoreHandler(userService))
+
+	// Approval of the device logins of the command line
+	e.GET("/device", web.DeviceFormHandler(ssoService != nil))
+	e.POST("/device", web.DeviceHandler(authService, ssoService != nil))
+	e.GET("/device/approved", web.DeviceApprovedHandler())
+
+	// Single sign-on through the identity provider
+	if ssoService != nil {
+		e.GET("/auth/oidc/login", ssoService.LoginHandler)
+		e.GET("/auth/oidc/callback", ssoService.CallbackHandler)
+	}
+
 	// Serve Swagger UI from embedded files
 	group := e.Group("swagger")
 	group.Use(middleware.StaticWithConfig(middleware.StaticConfig{
@@ -144,3 +313,19 @@ func startServer() error {
 	log.Info().Int("port", viper.GetInt("http.port")).Msg("Starting HTTP server")
 	return e.StartH2CServer(fmt.Sprintf(":%d", viper.GetInt("http.port")), s)
 }
+
+// baseURLSetting returns http.base_url, falling back to the BASE_URL variable
+// read by earlier versions
+func baseURLSetting() string {
+	if viper.InConfig("http.base_url") || os.Getenv("ALIASME_HTTP_BASE_URL") != "" {
+		return viper.GetString("http.base_url")
+	}
+
+	if legacy := os.Getenv("BASE_URL"); legacy != "" {
+		log.Warn().Msg("BASE_URL is deprecated, set http.base_url or ALIASME_HTTP_BASE_URL instead")
+		return legacy
+	}
+
+	log.Warn().Str("base_url", viper.GetString("http.base_url")).Msg("http.base_url is not set, emailed links use the default")
+	return viper.GetString("http.base_url")
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage personal access tokens",
+	Long:  `Create, list and revoke personal access tokens for scripts and extensions. Requires a login access token.`,
+}
+
+var createTokenCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a personal access token",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		req := &aliasme.CreateTokenRequest{
+			Name:   args[0],
+			Scopes: viper.GetStringSlice("token.scopes"),
+		}
+		if ttl := viper.GetDuration("token.expires_in"); ttl > 0 {
+			req.ExpiresAt = timestamppb.New(time.Now().Add(ttl))
+		}
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewAuthServiceClient(conn)
+		resp, err := client.CreateToken(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create token: %w", err)
+		}
+
+		fmt.Printf("Created token %s (%s)\n", resp.Info.Id, strings.Join(resp.Info.Scopes, ", "))
+		fmt.Println("Store it now, it will not be shown again:")
+		fmt.Println(resp.Token)
+		return nil
+	},
+}
+
+var listTokensCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List personal access tokens",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewAuthServiceClient(conn)
+		resp, err := client.ListTokens(ctx, &aliasme.ListTokensRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list tokens: %w", err)
+		}
+
+		if len(resp.Tokens) == 0 {
+			fmt.Println("No tokens found")
+			return nil
+		}
+
+		for _, token := range resp.Tokens {
+			fmt.Printf("- id: %s, name: %s, scopes: %s, expires: %s, last used: %s%s\n",
+				token.Id, token.Name, strings.Join(token.Scopes, " "),
+				formatTokenTime(token.ExpiresAt, "never"), formatTokenTime(token.LastUsedAt, "never"),
+				revokedSuffix(token.RevokedAt))
+		}
+		return nil
+	},
+}
+
+var revokeTokenCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke a personal access token",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewAuthServiceClient(conn)
+		if _, err := client.RevokeToken(ctx, &aliasme.RevokeTokenRequest{Id: args[0]}); err != nil {
+			return fmt.Errorf("failed to revoke token: %w", err)
+		}
+
+		fmt.Printf("Revoked token %s\n", args[0])
+		return nil
+	},
+}
+
+// formatTokenTime formats an optional token timestamp
+func formatTokenTime(t *timestamppb.Timestamp, unset string) string {
+	if t == nil {
+		return unset
+	}
+
+	return t.AsTime().Local().Format(time.RFC1123)
+}
+
+// revokedSuffix marks revoked tokens in listings
+func revokedSuffix(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return ""
+	}
+
+	return ", revoked: " + formatTokenTime(t, "")
+}
+
+func init() {
+	clientCmd.AddCommand(tokenCmd)
+	tokenCmd.AddCommand(createTokenCmd, listTokensCmd, revokeTokenCmd)
+
+	createTokenCmd.Flags().StringSlice("scope", nil, "Granted scope, repeatable: aliases:read, aliases:write, emails:write or admin")
+	createTokenCmd.Flags().Duration("expires-in", 0, "Token lifetime, e.g. 720h (default: never expires)")
+
+	if err := viper.BindPFlag("token.scopes", createTokenCmd.Flags().Lookup("scope")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding scope flag: %v\n", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("token.expires_in", createTokenCmd.Flags().Lookup("expires-in")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding expires-in flag: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+)
+
+var totpCmd = &cobra.Command{
+	Use:   "2fa",
+	Short: "Manage two-factor authentication",
+	Long:  `Enroll, confirm and disable a TOTP authenticator. Requires a login access token.`,
+}
+
+var enrollTOTPCmd = &cobra.Command{
+	Use:   "enroll",
+	Short: "Start enrolling a TOTP authenticator",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewAuthServiceClient(conn)
+		resp, err := client.EnrollTOTP(ctx, &aliasme.EnrollTOTPRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to enroll TOTP: %w", err)
+		}
+
+		fmt.Println("Add this account to your authenticator app:")
+		fmt.Println(resp.OtpauthUri)
+		fmt.Printf("or enter the secret manually: %s\n", resp.Secret)
+		fmt.Println("Then run `aliasme client 2fa confirm <code>` to enable two-factor authentication")
+		return nil
+	},
+}
+
+var confirmTOTPCmd = &cobra.Command{
+	Use:   "confirm <code>",
+	Short: "Confirm the TOTP enrolment with a code of the authenticator",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewAuthServiceClient(conn)
+		resp, err := client.ConfirmTOTP(ctx, &aliasme.ConfirmTOTPRequest{Code: args[0]})
+		if err != nil {
+			return fmt.Errorf("failed to confirm TOTP: %w", err)
+		}
+
+		fmt.Println("Two-factor authentication is enabled")
+		fmt.Println("Store these recovery codes now, each works once and they will not be shown again:")
+		for _, code := range resp.RecoveryCodes {
+			fmt.Println(code)
+		}
+		return nil
+	},
+}
+
+var disableTOTPCmd = &cobra.Command{
+	Use:   "disable <code>",
+	Short: "Disable two-factor authentication with a TOTP or recovery code",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewAuthServiceClient(conn)
+		if _, err := client.DisableTOTP(ctx, &aliasme.DisableTOTPRequest{Code: args[0]}); err != nil {
+			return fmt.Errorf("failed to disable TOTP: %w", err)
+		}
+
+		fmt.Println("Two-factor authentication is disabled")
+		return nil
+	},
+}
+
+var resetTOTPCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Reset the two-factor authentication of a user (admin)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		userID := viper.GetString("totp.user_id")
+		if userID == "" {
+			return fmt.Errorf("--user-id is required")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := grpc.NewClient(
+			fmt.Sprintf("localhost:%d", viper.GetInt("grpc.port")),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithPerRPCCredentials(clientCredentials(viper.GetString("client.token"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to gRPC server: %w", err)
+		}
+		defer conn.Close()
+
+		client := aliasme.NewAdminServiceClient(conn)
+		if _, err := client.ResetTOTP(ctx, &aliasme.ResetTOTPRequest{UserId: userID}); err != nil {
+			return fmt.Errorf("failed to reset TOTP: %w", err)
+		}
+
+		fmt.Printf("Two-factor authentication of user %s is reset\n", userID)
+		return nil
+	},
+}
+
+func init() {
+	clientCmd.AddCommand(totpCmd)
+	totpCmd.AddCommand(enrollTOTPCmd, confirmTOTPCmd, disableTOTPCmd, resetTOTPCmd)
+
+	resetTOTPCmd.Flags().String("user-id", "", "ID of the user whose two-factor authentication is reset")
+
+	if err := viper.BindPFlag("totp.user_id", resetTOTPCmd.Flags().Lookup("user-id")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding user-id flag: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 AliasMe
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// defaultQueueLimit is the number of queued mails listed when no limit is
+// requested
+const defaultQueueLimit = 100
+
+// Service handles administrative operations
+type Service struct {
+	aliasme.UnimplementedAdminServiceServer
+	db *gorm.DB
+}
+
+// New creates a new admin service
+func New(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+// ListMailQueue lists the outgoing mails, most recent first
+func (s *Service) ListMailQueue(ctx context.Context, req *aliasme.ListMailQueueRequest) (*aliasme.ListMailQueueResponse, error) {
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	query := s.db.Order("created_at DESC")
+
+	switch req.Status {
+	case "":
+	case models.MailStatusPending, models.MailStatusSent, models.MailStatusDead:
+		query = query.Where("status = ?", req.Status)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown mail status %q", req.Status)
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultQueueLimit
+	}
+
+	var mails []models.OutgoingMail
+	if err := query.Limit(limit).Find(&mails).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to list mail queue")
+		return nil, status.Error(codes.Internal, "failed to list mail queue")
+	}
+
+	messages := make([]*aliasme.OutgoingMail, len(mails))
+	for i := range mails {
+		messages[i] = outgoingMailToProto(&mails[i])
+	}
+
+	return &aliasme.ListMailQueueResponse{Messages: messages}, nil
+}
+
+// RetryMail schedules a pending or dead mail for immediate delivery. The body
+// of sent mails is discarded, so they cannot be retried.
+func (s *Service) RetryMail(ctx context.Context, req *aliasme.RetryMailRequest) (*aliasme.OutgoingMail, error) {
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var mail models.OutgoingMail
+	if err := s.db.First(&mail, "id = ?", req.Id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "mail not found")
+		}
+		log.Error().Err(err).Msg("Failed to get queued mail")
+		return nil, status.Error(codes.Internal, "failed to get queued mail")
+	}
+
+	if mail.Status == models.MailStatusSent {
+		return nil, status.Error(codes.FailedPrecondition, "mail was already sent")
+	}
+
+	now := time.Now()
+	mail.Status = models.MailStatusPending
+	mail.Attempts = 0
+	mail.NextAttemptAt = now
+	mail.UpdatedAt = now
+
+	if err := s.db.Save(&mail).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to update queued mail")
+		return nil, status.Error(codes.Internal, "failed to update queued mail")
+	}
+
+	return outgoingMailToProto(&mail), nil
+}
+
+// ResetTOTP disables the two-factor authentication of a user, who can then
+// log in with their password alone and enroll again
+func (s *Service) ResetTOTP(ctx context.Context, req *aliasme.ResetTOTPRequest) (*aliasme.ResetTOTPResponse, error) {
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := auth.ClearTOTP(s.db, req.UserId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		log.Error().Err(err).Msg("Failed to reset TOTP")
+		return nil, status.Error(codes.Internal, "failed to reset TOTP")
+	}
+
+	log.Info().Str("user_id", req.UserId).Msg("Two-factor authentication reset by an admin")
+	return &aliasme.ResetTOTPResponse{Success: true}, nil
+}
+
+// outgoingMailToProto converts a queued mail to its API representation,
+// leaving out the message body
+func outgoingMailToProto(mail *models.OutgoingMail) *aliasme.OutgoingMail {
+	pb := &aliasme.OutgoingMail{
+		Id:            mail.ID,
+		Recipient:     mail.Recipient,
+		Subject:       mail.Subject,
+		Status:        mail.Status,
+		Attempts:      int32(mail.Attempts),
+		LastError:     mail.LastError,
+		NextAttemptAt: timestamppb.New(mail.NextAttemptAt),
+		CreatedAt:     timestamppb.New(mail.CreatedAt),
+	}
+	if mail.SentAt != nil {
+		pb.SentAt = timestamppb.New(*mail.SentAt)
+	}
+
+	return pb
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/golgoth31/aliasme/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Identity is the authenticated caller of an RPC, through either a login
+// session or a personal access token
+type Identity struct {
+	UserID    string
+	SessionID string
+	TokenID   string
+	// Role is the role of the user
+	Role string
+	// Scopes restricts personal access tokens; sessions have full access
+	Scopes []string
+}
+
+// IsAdmin reports whether the caller has admin rights. Personal access tokens
+// of admins also need the admin scope.
+func (i *Identity) IsAdmin() bool {
+	return i.Role == models.RoleAdmin && (i.TokenID == "" || hasScope(i.Scopes, ScopeAdmin))
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a context carrying the identity
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity of the caller, if authenticated
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+// ActingUser returns the ID of the user an RPC acts for: the authenticated
+// user when the user ID of the request is empty. Only admins may act on
+// behalf of another user.
+func ActingUser(ctx context.Context, requested string) (string, error) {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if requested == "" {
+		return identity.UserID, nil
+	}
+	if requested != identity.UserID && !identity.IsAdmin() {
+		return "", status.Error(codes.PermissionDenied, "cannot act on behalf of another user")
+	}
+
+	return requested, nil
+}
+
+// Authorize checks that the caller may access a resource of the user
+// ownerID: its owner or an admin
+func Authorize(ctx context.Context, ownerID string) error {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if ownerID != identity.UserID && !identity.IsAdmin() {
+		return status.Error(codes.PermissionDenied, "resource belongs to another user")
+	}
+
+	return nil
+}
+
+// RequireAdmin checks that the caller is an admin
+func RequireAdmin(ctx context.Context) error {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if !identity.IsAdmin() {
+		return status.Error(codes.PermissionDenied, "admin role required")
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"google.golang.org/grpc/codes"
+)
+
+// Identities of the ownership tests
+var (
+	aliceSession = &Identity{UserID: "alice", SessionID: "session", Role: models.RoleUser}
+	aliceToken   = &Identity{UserID: "alice", TokenID: "token", Role: models.RoleUser, Scopes: []string{ScopeAdmin}}
+	adminSession = &Identity{UserID: "admin", SessionID: "session", Role: models.RoleAdmin}
+	adminToken   = &Identity{UserID: "admin", TokenID: "token", Role: models.RoleAdmin, Scopes: []string{ScopeAdmin}}
+	// Admin rights need the admin scope
+	adminReadToken = &Identity{UserID: "admin", TokenID: "token", Role: models.RoleAdmin, Scopes: []string{ScopeAliasesRead}}
+)
+
+// contextOf returns a context carrying the identity, if any
+func contextOf(identity *Identity) context.Context {
+	if identity == nil {
+		return context.Background()
+	}
+
+	return WithIdentity(context.Background(), identity)
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity *Identity
+		owner    string
+		want     codes.Code
+	}{
+		{"anonymous", nil, "alice", codes.Unauthenticated},
+		{"owner", aliceSession, "alice", codes.OK},
+		{"owner token", aliceToken, "alice", codes.OK},
+		{"other user", aliceSession, "bob", codes.PermissionDenied},
+		{"other user with admin scope", aliceToken, "bob", codes.PermissionDenied},
+		{"admin", adminSession, "bob", codes.OK},
+		{"admin token", adminToken, "bob", codes.OK},
+		{"admin token without admin scope", adminReadToken, "bob", codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertCode(t, Authorize(contextOf(tt.identity), tt.owner), tt.want)
+		})
+	}
+}
+
+func TestActingUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		identity  *Identity
+		requested string
+		want      string
+		code      codes.Code
+	}{
+		{"anonymous", nil, "", "", codes.Unauthenticated},
+		{"self by default", aliceSession, "", "alice", codes.OK},
+		{"self", aliceSession, "alice", "alice", codes.OK},
+		{"other user", aliceSession, "bob", "", codes.PermissionDenied},
+		{"admin on behalf", adminSession, "bob", "bob", codes.OK},
+		{"admin token without admin scope", adminReadToken, "bob", "", codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ActingUser(contextOf(tt.identity), tt.requested)
+			assertCode(t, err, tt.code)
+			if got != tt.want {
+				t.Fatalf("acting user = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity *Identity
+		want     codes.Code
+	}{
+		{"anonymous", nil, codes.Unauthenticated},
+		{"user", aliceSession, codes.PermissionDenied},
+		{"user token with admin scope", aliceToken, codes.PermissionDenied},
+		{"admin", adminSession, codes.OK},
+		{"admin token", adminToken, codes.OK},
+		{"admin token without admin scope", adminReadToken, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertCode(t, RequireAdmin(contextOf(tt.identity)), tt.want)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/utils"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// User codes are read from one screen and typed on another, so they avoid
+// vowels and look-alike characters (RFC 8628, section 6.1)
+const (
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength   = 8
+)
+
+// pollLeeway tolerates network jitter between two polls at the interval
+const pollLeeway = time.Second
+
+// deviceApprovalTTL is how long a user logged in with single sign-on has to
+// confirm the approval of a device login
+const deviceApprovalTTL = 5 * time.Minute
+
+// StartDeviceLogin starts a device login, approved by the user in a browser
+func (s *Service) StartDeviceLogin(ctx context.Context, req *aliasme.StartDeviceLoginRequest) (*aliasme.StartDeviceLoginResponse, error) {
+	deviceCode, err := generateSecret()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate device code")
+		return nil, status.Error(codes.Internal, "failed to start device login")
+	}
+	userCode, err := generateUserCode()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate user code")
+		return nil, status.Error(codes.Internal, "failed to start device login")
+	}
+
+	now := time.Now()
+	if err := s.db.Where("expires_at <= ?", now).Delete(&models.DeviceLogin{}).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to delete expired device logins")
+		return nil, status.Error(codes.Internal, "failed to start device login")
+	}
+
+	login := &models.DeviceLogin{
+		ID:             xid.New().String(),
+		DeviceCodeHash: utils.HashToken(s.config.Secret, deviceCode),
+		UserCode:       userCode,
+		Status:         models.DeviceLoginPending,
+		ExpiresAt:      now.Add(s.config.DeviceCodeTTL),
+		CreatedAt:      now,
+	}
+	if err := s.db.Create(login).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to create device login")
+		return nil, status.Error(codes.Internal, "failed to start device login")
+	}
+
+	formatted := FormatUserCode(userCode)
+	return &aliasme.StartDeviceLoginResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                formatted,
+		VerificationUri:         s.config.DeviceVerificationURL,
+		VerificationUriComplete: s.config.DeviceVerificationURL + "?" + url.Values{"user_code": {formatted}}.Encode(),
+		ExpiresAt:               timestamppb.New(login.ExpiresAt),
+		Interval:                int32(s.config.DevicePollInterval / time.Second),
+	}, nil
+}
+
+// PollDeviceLogin opens a session once the user approved the device login.
+// Each approval issues tokens once.
+func (s *Service) PollDeviceLogin(ctx context.Context, req *aliasme.PollDeviceLoginRequest) (*aliasme.TokenResponse, error) {
+	if req.DeviceCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_code is required")
+	}
+
+	var login models.DeviceLogin
+	err := s.db.First(&login, "device_code_hash = ?", utils.HashToken(s.config.Secret, req.DeviceCode)).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "invalid device code")
+		}
+		log.Error().Err(err).Msg("Failed to get device login")
+		return nil, status.Error(codes.Internal, "failed to get device login")
+	}
+
+	now := time.Now()
+	if !now.Before(login.ExpiresAt) {
+		return nil, status.Error(codes.DeadlineExceeded, "device login expired")
+	}
+
+	tooFast := login.LastPolledAt != nil && now.Sub(*login.LastPolledAt) < s.config.DevicePollInterval-pollLeeway
+	if err := s.db.Model(&login).UpdateColumn("last_polled_at", now).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to update device login")
+		return nil, status.Error(codes.Internal, "failed to get device login")
+	}
+	if tooFast {
+		return nil, status.Error(codes.ResourceExhausted, "slow down")
+	}
+
+	switch login.Status {
+	case models.DeviceLoginApproved:
+		// Concurrent polls of the same approval: only one gets the tokens
+		result := s.db.Delete(&models.DeviceLogin{}, "id = ?", login.ID)
+		if result.Error != nil {
+			log.Error().Err(result.Error).Msg("Failed to delete device login")
+			return nil, status.Error(codes.Internal, "failed to get device login")
+		}
+		if result.RowsAffected == 0 {
+			return nil, status.Error(codes.Unauthenticated, "invalid device code")
+		}
+		return s.OpenSession(login.UserID)
+	case models.DeviceLoginDenied:
+		if err := s.db.Delete(&models.DeviceLogin{}, "id = ?", login.ID).Error; err != nil {
+			log.Error().Err(err).Msg("Failed to delete device login")
+		}
+		return nil, status.Error(codes.PermissionDenied, "device login denied")
+	default:
+		return nil, status.Error(codes.FailedPrecondition, "authorization pending")
+	}
+}
+
+// ApproveDevice approves the pending device login of the user code for the
+// user
+func (s *Service) ApproveDevice(userCode, userID string) error {
+	if err := s.decideDevice(userCode, map[string]any{"status": models.DeviceLoginApproved, "user_id": userID}); err != nil {
+		return err
+	}
+	log.Info().Str("user_id", userID).Msg("Approved device login")
+
+	return nil
+}
+
+// DeviceApprovalToken returns a short-lived token standing for the login of
+// the user, with which they confirm the approval of the pending device login
+// of the user code. Logging in does not approve the device by itself, so a
+// link cannot make someone approve a device login they did not start.
+func (s *Service) DeviceApprovalToken(userCode, userID string) (string, error) {
+	var pending int64
+	err := s.db.Model(&models.DeviceLogin{}).
+		Where("user_code = ? AND status = ? AND expires_at > ?", normalizeUserCode(userCode), models.DeviceLoginPending, time.Now()).
+		Count(&pending).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get device login")
+		return "", status.Error(codes.Internal, "failed to get device login")
+	}
+	if pending == 0 {
+		return "", status.Error(codes.NotFound, "invalid or expired code")
+	}
+
+	payload := userID + "." + strconv.FormatInt(time.Now().Add(deviceApprovalTTL).Unix(), 10)
+	return payload + "." + s.deviceApprovalMAC(userCode, payload), nil
+}
+
+// ApproveDeviceWithToken approves the pending device login of the user code
+// for the user of the approval token
+func (s *Service) ApproveDeviceWithToken(userCode, token string) error {
+	userID, rest, _ := strings.Cut(token, ".")
+	expires, mac, _ := strings.Cut(rest, ".")
+	if !hmac.Equal([]byte(mac), []byte(s.deviceApprovalMAC(userCode, userID+"."+expires))) {
+		return status.Error(codes.Unauthenticated, "invalid approval token")
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() >= expiresAt {
+		return status.Error(codes.Unauthenticated, "approval token expired")
+	}
+
+	return s.ApproveDevice(userCode, userID)
+}
+
+// deviceApprovalMAC authenticates the payload of an approval token for the
+// user code
+func (s *Service) deviceApprovalMAC(userCode, payload string) string {
+	return utils.HashToken(utils.DeriveKey(s.config.Secret, "device-approval"), normalizeUserCode(userCode)+"."+payload)
+}
+
+// DenyDevice denies the pending device login of the user code
+func (s *Service) DenyDevice(userCode string) error {
+	return s.decideDevice(userCode, map[string]any{"status": models.DeviceLoginDenied})
+}
+
+// decideDevice updates the pending device login of the user code
+func (s *Service) decideDevice(userCode string, updates map[string]any) error {
+	result := s.db.Model(&models.DeviceLogin{}).
+		Where("user_code = ? AND status = ? AND expires_at > ?", normalizeUserCode(userCode), models.DeviceLoginPending, time.Now()).
+		Updates(updates)
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Failed to update device login")
+		return status.Error(codes.Internal, "failed to update device login")
+	}
+	if result.RowsAffected == 0 {
+		return status.Error(codes.NotFound, "invalid or expired code")
+	}
+
+	return nil
+}
+
+// generateUserCode generates a random user code, without separator
+func generateUserCode() (string, error) {
+	code := make([]byte, 0, userCodeLength)
+	b := make([]byte, 1)
+	for len(code) < userCodeLength {
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		// Rejecting the last bytes keeps the characters equally likely
+		if int(b[0]) >= 256-256%len(userCodeAlphabet) {
+			continue
+		}
+		code = append(code, userCodeAlphabet[int(b[0])%len(userCodeAlphabet)])
+	}
+
+	return string(code), nil
+}
+
+// normalizeUserCode returns a user code as typed by the user without
+// separators and in upper case
+func normalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// FormatUserCode returns a user code in the XXXX-XXXX form shown to users
+func FormatUserCode(code string) string {
+	code = normalizeUserCode(code)
+	if len(code) != userCodeLength {
+		return code
+	}
+
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
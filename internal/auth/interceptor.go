@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// publicMethods can be called without authentication
+var publicMethods = map[string]bool{
+	aliasme.AuthService_Login_FullMethodName:                true,
+	aliasme.AuthService_Refresh_FullMethodName:              true,
+	aliasme.AuthService_Logout_FullMethodName:               true,
+	aliasme.AuthService_RequestPasswordReset_FullMethodName: true,
+	aliasme.AuthService_ResetPassword_FullMethodName:        true,
+	aliasme.AuthService_StartDeviceLogin_FullMethodName:     true,
+	aliasme.AuthService_PollDeviceLogin_FullMethodName:      true,
+	aliasme.UserService_CreateUser_FullMethodName:           true,
+	aliasme.UserService_RestoreUser_FullMethodName:          true,
+	aliasme.EmailService_VerifyEmail_FullMethodName:         true,
+}
+
+// UnaryInterceptor authenticates the callers of unary RPCs
+func (s *Service) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := s.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authenticates the callers of streaming RPCs
+func (s *Service) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := s.authenticate(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticatedStream overrides the context of a stream with the identity
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying the identity
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate verifies the bearer token of the call and returns the context
+// carrying the caller identity. Public methods pass through.
+func (s *Service) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if publicMethods[method] {
+		return ctx, nil
+	}
+
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(token, tokenPrefix) {
+		return s.authenticateToken(ctx, method, token)
+	}
+
+	identity, err := s.AuthenticateSession(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return WithIdentity(ctx, identity), nil
+}
+
+// AuthenticateSession verifies an access token and returns the identity of its
+// session. Personal access tokens are rejected.
+func (s *Service) AuthenticateSession(token string) (*Identity, error) {
+	claims, err := s.parseAccessToken(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid access token")
+	}
+
+	// Revoked sessions and deleted users lose access right away, and role
+	// changes apply to the next call
+	var user models.User
+	err = s.db.Select("users.id", "users.role").
+		Joins("JOIN sessions ON sessions.user_id = users.id").
+		Where("sessions.id = ? AND sessions.user_id = ? AND sessions.revoked_at IS NULL AND sessions.expires_at > ?",
+			claims.SessionID, claims.Subject, time.Now()).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "session expired or revoked")
+		}
+		log.Error().Err(err).Msg("Failed to get session")
+		return nil, status.Error(codes.Internal, "failed to get session")
+	}
+
+	return &Identity{UserID: user.ID, SessionID: claims.SessionID, Role: user.Role}, nil
+}
+
+// bearerToken returns the token of the authorization metadata, which the
+// gateway fills from the Authorization header
+func bearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, tokenType) || token == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+
+	return strings.TrimSpace(token), nil
+}
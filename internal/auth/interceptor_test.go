@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// serviceDescs are the services served behind the interceptors
+var serviceDescs = []grpc.ServiceDesc{
+	aliasme.AuthService_ServiceDesc,
+	aliasme.UserService_ServiceDesc,
+	aliasme.EmailService_ServiceDesc,
+	aliasme.AdminService_ServiceDesc,
+}
+
+// testStream is a server stream only carrying a context
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+// rpc describes a method for the interceptor tests
+type rpc struct {
+	method string
+	stream bool
+}
+
+// allRPCs lists the methods of every service
+func allRPCs() []rpc {
+	var rpcs []rpc
+	for _, desc := range serviceDescs {
+		for _, m := range desc.Methods {
+			rpcs = append(rpcs, rpc{method: "/" + desc.ServiceName + "/" + m.MethodName})
+		}
+		for _, st := range desc.Streams {
+			rpcs = append(rpcs, rpc{method: "/" + desc.ServiceName + "/" + st.StreamName, stream: true})
+		}
+	}
+
+	return rpcs
+}
+
+// call runs the interceptor of the method and returns the identity the
+// handler got, if called
+func call(ctx context.Context, s *Service, r rpc) (*Identity, bool, error) {
+	var (
+		identity *Identity
+		called   bool
+	)
+	if r.stream {
+		err := s.StreamInterceptor()(nil, &testStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: r.method},
+			func(srv any, stream grpc.ServerStream) error {
+				called = true
+				identity, _ = FromContext(stream.Context())
+				return nil
+			})
+		return identity, called, err
+	}
+
+	_, err := s.UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: r.method},
+		func(ctx context.Context, req any) (any, error) {
+			called = true
+			identity, _ = FromContext(ctx)
+			return nil, nil
+		})
+	return identity, called, err
+}
+
+// withAuthorization returns an incoming context with the authorization
+// metadata
+func withAuthorization(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+}
+
+// tamper replaces the subject of a signed token, keeping its signature
+func tamper(t *testing.T, token, subject string) string {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatal(err)
+	}
+	claims["sub"] = subject
+	payload, err = json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+
+	return strings.Join(parts, ".")
+}
+
+func TestInterceptorsRejectInvalidCredentials(t *testing.T) {
+	s, _ := newTestService(t)
+	alice := createUser(t, s, "alice")
+	bob := createUser(t, s, "bob")
+
+	tokens, err := s.OpenSession(alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var session models.Session
+	if err := s.db.First(&session, "user_id = ?", alice.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	expired, err := s.signAccessToken(alice.ID, session.ID, time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := newTestService(t)
+	other.signingKey = []byte("another key")
+	foreign, err := other.signAccessToken(alice.ID, session.ID, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revoked, err := s.OpenSession(bob.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Logout(context.Background(), &aliasme.LogoutRequest{RefreshToken: revoked.RefreshToken}); err != nil {
+		t.Fatal(err)
+	}
+
+	credentials := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"missing", context.Background()},
+		{"empty metadata", metadata.NewIncomingContext(context.Background(), metadata.MD{})},
+		{"not bearer", withAuthorization("Basic " + tokens.AccessToken)},
+		{"empty bearer", withAuthorization("Bearer ")},
+		{"garbage", withAuthorization("Bearer not-a-jwt")},
+		{"expired", withAuthorization("Bearer " + expired)},
+		{"tampered", withAuthorization("Bearer " + tamper(t, tokens.AccessToken, bob.ID))},
+		{"other signing key", withAuthorization("Bearer " + foreign)},
+		{"revoked session", withAuthorization("Bearer " + revoked.AccessToken)},
+		{"refresh token", withAuthorization("Bearer " + tokens.RefreshToken)},
+		{"unknown personal access token", withAuthorization("Bearer " + tokenPrefix + "unknown")},
+	}
+
+	checked := 0
+	for _, r := range allRPCs() {
+		if publicMethods[r.method] {
+			continue
+		}
+		checked++
+		for _, c := range credentials {
+			_, called, err := call(c.ctx, s, r)
+			if called {
+				t.Fatalf("%s with %s credentials reached the handler", r.method, c.name)
+			}
+			if err == nil {
+				t.Fatalf("%s with %s credentials: no error", r.method, c.name)
+			}
+			assertCode(t, err, codes.Unauthenticated)
+		}
+	}
+	if checked == 0 {
+		t.Fatal("no authenticated method")
+	}
+}
+
+func TestInterceptorsAcceptValidSessions(t *testing.T) {
+	s, _ := newTestService(t)
+	alice := createUser(t, s, "alice")
+	tokens, err := s.OpenSession(alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range allRPCs() {
+		identity, called, err := call(withAuthorization("Bearer "+tokens.AccessToken), s, r)
+		if err != nil || !called {
+			t.Fatalf("%s: called = %v, error = %v", r.method, called, err)
+		}
+		if publicMethods[r.method] {
+			continue
+		}
+		if identity == nil || identity.UserID != alice.ID || identity.SessionID == "" {
+			t.Fatalf("%s: identity = %+v, want the session of alice", r.method, identity)
+		}
+	}
+}
+
+func TestPublicMethodsNeedNoCredentials(t *testing.T) {
+	s, _ := newTestService(t)
+
+	for method := range publicMethods {
+		identity, called, err := call(context.Background(), s, rpc{method: method})
+		if err != nil || !called {
+			t.Fatalf("%s: called = %v, error = %v", method, called, err)
+		}
+		if identity != nil {
+			t.Fatalf("%s: unexpected identity %+v", method, identity)
+		}
+	}
+}
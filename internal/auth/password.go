@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/utils"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// maxPasswordLength is the number of bytes of a password bcrypt accepts
+const maxPasswordLength = 72
+
+// PasswordPolicy is the policy passwords must follow when they are set
+type PasswordPolicy struct {
+	MinLength        int
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+}
+
+// Check returns an InvalidArgument error naming the first rule the password
+// breaks
+func (p PasswordPolicy) Check(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return status.Errorf(codes.InvalidArgument, "password must be at least %d characters long", p.MinLength)
+	}
+	if len(password) > maxPasswordLength {
+		return status.Errorf(codes.InvalidArgument, "password must be at most %d bytes long", maxPasswordLength)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	switch {
+	case p.RequireLowercase && !lower:
+		return status.Error(codes.InvalidArgument, "password must contain a lowercase letter")
+	case p.RequireUppercase && !upper:
+		return status.Error(codes.InvalidArgument, "password must contain an uppercase letter")
+	case p.RequireDigit && !digit:
+		return status.Error(codes.InvalidArgument, "password must contain a digit")
+	case p.RequireSymbol && !symbol:
+		return status.Error(codes.InvalidArgument, "password must contain a symbol")
+	}
+
+	return nil
+}
+
+// Mailer sends the emails of the authentication flows
+type Mailer interface {
+	SendPasswordReset(tx *gorm.DB, to, token, locale string, ttl time.Duration) error
+}
+
+// ChangePassword changes the password of the authenticated user and revokes
+// their other sessions and personal access tokens
+func (s *Service) ChangePassword(ctx context.Context, req *aliasme.ChangePasswordRequest) (*aliasme.ChangePasswordResponse, error) {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	var user models.User
+	if err := s.db.First(&user, "id = ?", identity.UserID).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to get user")
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)) != nil {
+		return nil, status.Error(codes.PermissionDenied, "current password is incorrect")
+	}
+	if err := s.config.Password.Check(req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to hash password")
+		return nil, status.Error(codes.Internal, "failed to change password")
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&user).Updates(map[string]any{"password": string(hash), "updated_at": time.Now()}).Error; err != nil {
+			return err
+		}
+		if err := revokeSessions(tx, user.ID, identity.SessionID); err != nil {
+			return err
+		}
+		return revokeAccessTokens(tx, user.ID)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to change password")
+		return nil, status.Error(codes.Internal, "failed to change password")
+	}
+
+	return &aliasme.ChangePasswordResponse{Success: true}, nil
+}
+
+// RequestPasswordReset emails a reset link to the user with the email. It
+// succeeds whether or not the email is known, and sends at most one link per
+// reset interval.
+func (s *Service) RequestPasswordReset(ctx context.Context, req *aliasme.RequestPasswordResetRequest) (*aliasme.RequestPasswordResetResponse, error) {
+	address := strings.TrimSpace(req.Email)
+	if address == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	var user models.User
+	if err := s.db.First(&user, "email = ?", address).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &aliasme.RequestPasswordResetResponse{}, nil
+		}
+		log.Error().Err(err).Msg("Failed to get user")
+		return nil, status.Error(codes.Internal, "failed to request password reset")
+	}
+	// Single sign-on users log in through their identity provider only
+	if user.OIDCSubject != "" {
+		return &aliasme.RequestPasswordResetResponse{}, nil
+	}
+
+	now := time.Now()
+	var recent int64
+	err := s.db.Model(&models.PasswordReset{}).
+		Where("user_id = ? AND created_at > ?", user.ID, now.Add(-s.config.ResetInterval)).
+		Count(&recent).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count password resets")
+		return nil, status.Error(codes.Internal, "failed to request password reset")
+	}
+	if recent > 0 {
+		return &aliasme.RequestPasswordResetResponse{}, nil
+	}
+
+	token, err := generateSecret()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate reset token")
+		return nil, status.Error(codes.Internal, "failed to request password reset")
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// Only the latest link works
+		if err := tx.Where("user_id = ? AND used_at IS NULL", user.ID).Delete(&models.PasswordReset{}).Error; err != nil {
+			return err
+		}
+
+		reset := &models.PasswordReset{
+			ID:        xid.New().String(),
+			UserID:    user.ID,
+			TokenHash: utils.HashToken(s.config.Secret, token),
+			ExpiresAt: now.Add(s.config.ResetTokenTTL),
+			CreatedAt: now,
+		}
+		if err := tx.Create(reset).Error; err != nil {
+			return err
+		}
+
+		return s.mailer.SendPasswordReset(tx, user.Email, token, user.Locale, s.config.ResetTokenTTL)
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create password reset")
+		return nil, status.Error(codes.Internal, "failed to request password reset")
+	}
+
+	return &aliasme.RequestPasswordResetResponse{}, nil
+}
+
+// ResetPassword sets a new password with a reset token, which is used up, and
+// revokes all the sessions and personal access tokens of the user
+func (s *Service) ResetPassword(ctx context.Context, req *aliasme.ResetPasswordRequest) (*aliasme.ResetPasswordResponse, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	// Checked first, so a rejected password does not use the token up
+	if err := s.config.Password.Check(req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	var reset models.PasswordReset
+	err := s.db.First(&reset, "token_hash = ? AND used_at IS NULL", utils.HashToken(s.config.Secret, req.Token)).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "invalid or already used reset token")
+		}
+		log.Error().Err(err).Msg("Failed to get password reset")
+		return nil, status.Error(codes.Internal, "failed to reset password")
+	}
+	if time.Now().After(reset.ExpiresAt) {
+		return nil, status.Error(codes.DeadlineExceeded, "reset token has expired")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to hash password")
+		return nil, status.Error(codes.Internal, "failed to reset password")
+	}
+
+	errUsed := errors.New("reset token already used")
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Concurrent resets with the same token: only one uses it
+		result := tx.Model(&models.PasswordReset{}).
+			Where("id = ? AND used_at IS NULL", reset.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errUsed
+		}
+
+		result = tx.Model(&models.User{}).
+			Where("id = ?", reset.UserID).
+			Updates(map[string]any{"password": string(hash), "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errUsed
+		}
+
+		if err := revokeSessions(tx, reset.UserID, ""); err != nil {
+			return err
+		}
+		return revokeAccessTokens(tx, reset.UserID)
+	})
+	if err != nil {
+		if errors.Is(err, errUsed) {
+			return nil, status.Error(codes.NotFound, "invalid or already used reset token")
+		}
+		log.Error().Err(err).Msg("Failed to reset password")
+		return nil, status.Error(codes.Internal, "failed to reset password")
+	}
+
+	return &aliasme.ResetPasswordResponse{Success: true}, nil
+}
+
+// revokeSessions revokes the active sessions of a user but the kept one
+func revokeSessions(tx *gorm.DB, userID, keep string) error {
+	return tx.Model(&models.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keep).
+		Update("revoked_at", time.Now()).Error
+}
+
+// revokeAccessTokens revokes the personal access tokens of a user
+func revokeAccessTokens(tx *gorm.DB, userID string) error {
+	return tx.Model(&models.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeCredentials ends the sessions of a user and revokes their personal
+// access tokens, pending password resets and device logins. Passkeys and the
+// TOTP secret are kept, as they only work for existing users.
+func RevokeCredentials(tx *gorm.DB, userID string) error {
+	if err := revokeSessions(tx, userID, ""); err != nil {
+		return err
+	}
+	if err := revokeAccessTokens(tx, userID); err != nil {
+		return err
+	}
+
+	for _, model := range []any{&models.PasswordReset{}, &models.DeviceLogin{}, &models.WebAuthnChallenge{}} {
+		if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+)
+
+// requestReset requests a password reset of the user and returns the token
+// that was mailed
+func requestReset(t *testing.T, s *Service, mailer *recordingMailer, user *models.User) string {
+	t.Helper()
+
+	sent := len(mailer.tokens)
+	if _, err := s.RequestPasswordReset(context.Background(), &aliasme.RequestPasswordResetRequest{Email: user.Email}); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	if len(mailer.tokens) != sent+1 {
+		t.Fatal("no reset link was mailed")
+	}
+
+	return mailer.tokens[sent]
+}
+
+// assertPassword fails the test unless password is the password of the user
+func assertPassword(t *testing.T, s *Service, user *models.User, password string) {
+	t.Helper()
+
+	var stored models.User
+	if err := s.db.First(&stored, "id = ?", user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(stored.Password), []byte(password)) != nil {
+		t.Fatalf("password is not %q", password)
+	}
+}
+
+// sessionOf returns the ID of the session the tokens belong to
+func sessionOf(t *testing.T, s *Service, tokens *aliasme.TokenResponse) string {
+	t.Helper()
+
+	identity, err := s.AuthenticateSession(tokens.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return identity.SessionID
+}
+
+func TestResetTokenIsSingleUse(t *testing.T) {
+	s, mailer := newTestService(t)
+	alice := createUser(t, s, "alice")
+	token := requestReset(t, s, mailer, alice)
+
+	if _, err := s.ResetPassword(context.Background(), &aliasme.ResetPasswordRequest{Token: token, NewPassword: "new password 1"}); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	assertPassword(t, s, alice, "new password 1")
+
+	_, err := s.ResetPassword(context.Background(), &aliasme.ResetPasswordRequest{Token: token, NewPassword: "new password 2"})
+	assertCode(t, err, codes.NotFound)
+	assertPassword(t, s, alice, "new password 1")
+}
+
+func TestOnlyTheLatestResetTokenWorks(t *testing.T) {
+	s, mailer := newTestService(t)
+	alice := createUser(t, s, "alice")
+	first := requestReset(t, s, mailer, alice)
+	latest := requestReset(t, s, mailer, alice)
+
+	_, err := s.ResetPassword(context.Background(), &aliasme.ResetPasswordRequest{Token: first, NewPassword: "new password 1"})
+	assertCode(t, err, codes.NotFound)
+	if _, err := s.ResetPassword(context.Background(), &aliasme.ResetPasswordRequest{Token: latest, NewPassword: "new password 1"}); err != nil {
+		t.Fatalf("ResetPassword with the latest token: %v", err)
+	}
+}
+
+func TestResetTokenExpires(t *testing.T) {
+	s, mailer := newTestService(t)
+	alice := createUser(t, s, "alice")
+	token := requestReset(t, s, mailer, alice)
+
+	var reset models.PasswordReset
+	if err := s.db.First(&reset, "user_id = ?", alice.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if ttl := time.Until(reset.ExpiresAt); ttl <= 0 || ttl > s.config.ResetTokenTTL {
+		t.Fatalf("reset token expires in %s, want up to %s", ttl, s.config.ResetTokenTTL)
+	}
+	if err := s.db.Model(&reset).Update("expires_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := s.ResetPassword(context.Background(), &aliasme.ResetPasswordRequest{Token: token, NewPassword: "new password 1"})
+	assertCode(t, err, codes.DeadlineExceeded)
+	assertPassword(t, s, alice, testPassword)
+}
+
+func TestResetPasswordRevokesEverySession(t *testing.T) {
+	s, mailer := newTestService(t)
+	alice := createUser(t, s, "alice")
+	bob := createUser(t, s, "bob")
+
+	sessions := make([]*aliasme.TokenResponse, 2)
+	for i := range sessions {
+		tokens, err := s.OpenSession(alice.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sessions[i] = tokens
+	}
+	pat, _ := createToken(t, s, alice, ScopeAliasesRead)
+	other, err := s.OpenSession(bob.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := requestReset(t, s, mailer, alice)
+	if _, err := s.ResetPassword(context.Background(), &aliasme.ResetPasswordRequest{Token: token, NewPassword: "new password 1"}); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+
+	method := rpc{method: aliasme.EmailService_ListAliases_FullMethodName}
+	for i, tokens := range sessions {
+		_, err := s.Refresh(context.Background(), &aliasme.RefreshRequest{RefreshToken: tokens.RefreshToken})
+		assertCode(t, err, codes.Unauthenticated)
+		if _, called, _ := call(withAuthorization("Bearer "+tokens.AccessToken), s, method); called {
+			t.Fatalf("session %d still authenticates", i)
+		}
+	}
+	if _, called, _ := call(withAuthorization("Bearer "+pat), s, method); called {
+		t.Fatal("personal access token still authenticates")
+	}
+
+	// Other users keep their sessions
+	if _, err := s.Refresh(context.Background(), &aliasme.RefreshRequest{RefreshToken: other.RefreshToken}); err != nil {
+		t.Fatalf("session of bob: %v", err)
+	}
+}
+
+func TestChangePasswordRevokesOtherSessions(t *testing.T) {
+	s, _ := newTestService(t)
+	alice := createUser(t, s, "alice")
+
+	current, err := s.OpenSession(alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := s.OpenSession(alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithIdentity(context.Background(), &Identity{UserID: alice.ID, SessionID: sessionOf(t, s, current), Role: alice.Role})
+
+	_, err = s.ChangePassword(ctx, &aliasme.ChangePasswordRequest{CurrentPassword: "not the password", NewPassword: "new password 1"})
+	assertCode(t, err, codes.PermissionDenied)
+	assertPassword(t, s, alice, testPassword)
+
+	if _, err := s.ChangePassword(ctx, &aliasme.ChangePasswordRequest{CurrentPassword: testPassword, NewPassword: "new password 1"}); err != nil {
+		t.Fatalf("ChangePassword: %v", err)
+	}
+	assertPassword(t, s, alice, "new password 1")
+
+	_, err = s.Refresh(context.Background(), &aliasme.RefreshRequest{RefreshToken: other.RefreshToken})
+	assertCode(t, err, codes.Unauthenticated)
+	// The session that changed the password stays logged in
+	if _, err := s.Refresh(context.Background(), &aliasme.RefreshRequest{RefreshToken: current.RefreshToken}); err != nil {
+		t.Fatalf("current session: %v", err)
+	}
+}
+
+func TestPasswordPolicyIsEnforced(t *testing.T) {
+	s, mailer := newTestService(t)
+	alice := createUser(t, s, "alice")
+	tokens, err := s.OpenSession(alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithIdentity(context.Background(), &Identity{UserID: alice.ID, SessionID: sessionOf(t, s, tokens), Role: alice.Role})
+	token := requestReset(t, s, mailer, alice)
+
+	weak := map[string]string{
+		"too short": "short 1",
+		"no digit":  "long enough password",
+		"too long":  strings.Repeat("long password 1 ", maxPasswordLength/16+1),
+	}
+	for name, password := range weak {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.ChangePassword(ctx, &aliasme.ChangePasswordRequest{CurrentPassword: testPassword, NewPassword: password})
+			assertCode(t, err, codes.InvalidArgument)
+			_, err = s.ResetPassword(context.Background(), &aliasme.ResetPasswordRequest{Token: token, NewPassword: password})
+			assertCode(t, err, codes.InvalidArgument)
+		})
+	}
+	assertPassword(t, s, alice, testPassword)
+
+	// A rejected password does not use the reset token up
+	if _, err := s.ResetPassword(context.Background(), &aliasme.ResetPasswordRequest{Token: token, NewPassword: "new password 1"}); err != nil {
+		t.Fatalf("ResetPassword after rejected passwords: %v", err)
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2024 AliasMe
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/utils"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// tokenType is the type of the issued access tokens
+const tokenType = "Bearer"
+
+// Config holds the authentication configuration
+type Config struct {
+	// Secret is the server secret, from which the token signing key is
+	// derived
+	Secret []byte
+	// AccessTokenTTL is how long access tokens are valid
+	AccessTokenTTL time.Duration
+	// RefreshTokenTTL is how long a session lasts without being refreshed
+	RefreshTokenTTL time.Duration
+	// Password is the policy of new passwords
+	Password PasswordPolicy
+	// ResetTokenTTL is how long password reset links are valid
+	ResetTokenTTL time.Duration
+	// ResetInterval is the minimum delay between two reset emails to a user
+	ResetInterval time.Duration
+	// TOTPIssuer is the account issuer shown by authenticator apps
+	TOTPIssuer string
+	// TOTPMaxFailures is the number of invalid two-factor codes accepted
+	// before checks are locked for TOTPLockout, doubled on each further
+	// failure
+	TOTPMaxFailures int
+	TOTPLockout     time.Duration
+	// DeviceVerificationURL is the page where users approve device logins
+	DeviceVerificationURL string
+	// DeviceCodeTTL is how long device logins wait for approval
+	DeviceCodeTTL time.Duration
+	// DevicePollInterval is the minimum delay between two polls of a device
+	// login
+	DevicePollInterval time.Duration
+}
+
+// Service handles authentication
+type Service struct {
+	aliasme.UnimplementedAuthServiceServer
+	db         *gorm.DB
+	config     Config
+	signingKey []byte
+	mailer     Mailer
+}
+
+// New creates a new auth service
+func New(db *gorm.DB, cfg Config, mailer Mailer) *Service {
+	return &Service{
+		db:         db,
+		config:     cfg,
+		signingKey: utils.DeriveKey(cfg.Secret, "access-token"),
+		mailer:     mailer,
+	}
+}
+
+// ErrPasskeyRequired is returned to password logins of users whose second
+// factor is a passkey, which only the WebAuthn endpoints can check
+var ErrPasskeyRequired = status.Error(codes.PermissionDenied,
+	"passkey required, log in with /api/v1/webauthn/login/begin and /api/v1/webauthn/login/finish")
+
+// Login authenticates a user with a password, and a second factor when
+// enabled, and opens a session
+func (s *Service) Login(ctx context.Context, req *aliasme.LoginRequest) (*aliasme.TokenResponse, error) {
+	user, err := s.VerifyLogin(req.Login, req.Password, req.TotpCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.OpenSession(user.ID)
+}
+
+// VerifyLogin returns the user with the username or email if the password is
+// theirs, checking the TOTP code when enabled. Users with passkeys and no TOTP
+// get ErrPasskeyRequired.
+func (s *Service) VerifyLogin(login, password, code string) (*models.User, error) {
+	user, err := s.VerifyPassword(login, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		if code == "" {
+			return nil, status.Error(codes.FailedPrecondition, "second factor required")
+		}
+		ok, err := s.verifySecondFactor(user, code)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid two-factor code")
+		}
+		return user, nil
+	}
+
+	passkeys, err := s.countPasskeys(user.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count passkeys")
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+	if passkeys > 0 {
+		return nil, ErrPasskeyRequired
+	}
+
+	return user, nil
+}
+
+// VerifyPassword returns the user with the username or email if the password
+// is theirs. It does not check second factors. Single sign-on users have no
+// password.
+func (s *Service) VerifyPassword(login, password string) (*models.User, error) {
+	if login == "" || password == "" {
+		return nil, status.Error(codes.InvalidArgument, "login and password are required")
+	}
+
+	var user models.User
+	err := s.db.First(&user, "username = ? OR email = ?", login, login).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error().Err(err).Msg("Failed to get user")
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+
+	// Unknown and single sign-on users cost a hash comparison too, so they
+	// cannot be told apart by timing
+	hash := user.Password
+	if err != nil || user.OIDCSubject != "" {
+		hash = dummyHash()
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil || err != nil || user.OIDCSubject != "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid login or password")
+	}
+
+	return &user, nil
+}
+
+// countPasskeys returns the number of WebAuthn credentials of the user, which
+// make password logins need a second factor
+func (s *Service) countPasskeys(userID string) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.WebAuthnCredential{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Refresh rotates the refresh token of a session and issues a new access
+// token
+func (s *Service) Refresh(ctx context.Context, req *aliasme.RefreshRequest) (*aliasme.TokenResponse, error) {
+	session, err := s.activeSession(req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := generateSecret()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate refresh token")
+		return nil, status.Error(codes.Internal, "failed to refresh session")
+	}
+
+	now := time.Now()
+	previousHash := session.RefreshTokenHash
+	session.RefreshTokenHash = utils.HashToken(s.config.Secret, refreshToken)
+	session.ExpiresAt = now.Add(s.config.RefreshTokenTTL)
+	session.LastUsedAt = &now
+
+	// Only the current token of a session still active rotates it, so that a
+	// session revoked meanwhile stays revoked and concurrent refreshes with
+	// the same token cannot both succeed
+	result := s.db.Model(&models.Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, previousHash).
+		Updates(map[string]any{
+			"refresh_token_hash": session.RefreshTokenHash,
+			"expires_at":         session.ExpiresAt,
+			"last_used_at":       session.LastUsedAt,
+		})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Failed to update session")
+		return nil, status.Error(codes.Internal, "failed to refresh session")
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired refresh token")
+	}
+
+	return s.tokenResponse(session, refreshToken)
+}
+
+// Logout revokes the session of a refresh token. Unknown tokens are ignored.
+func (s *Service) Logout(ctx context.Context, req *aliasme.LogoutRequest) (*aliasme.LogoutResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+
+	err := s.db.Model(&models.Session{}).
+		Where("refresh_token_hash = ? AND revoked_at IS NULL", utils.HashToken(s.config.Secret, req.RefreshToken)).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to revoke session")
+		return nil, status.Error(codes.Internal, "failed to revoke session")
+	}
+
+	return &aliasme.LogoutResponse{Success: true}, nil
+}
+
+// OpenSession opens a session for the user and issues its tokens
+func (s *Service) OpenSession(userID string) (*aliasme.TokenResponse, error) {
+	refreshToken, err := generateSecret()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate refresh token")
+		return nil, status.Error(codes.Internal, "failed to create session")
+	}
+
+	now := time.Now()
+	session := &models.Session{
+		ID:               xid.New().String(),
+		UserID:           userID,
+		RefreshTokenHash: utils.HashToken(s.config.Secret, refreshToken),
+		ExpiresAt:        now.Add(s.config.RefreshTokenTTL),
+		LastUsedAt:       &now,
+		CreatedAt:        now,
+	}
+	if err := s.db.Create(session).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to create session")
+		return nil, status.Error(codes.Internal, "failed to create session")
+	}
+
+	return s.tokenResponse(session, refreshToken)
+}
+
+// activeSession returns the unexpired and unrevoked session of a refresh
+// token whose user still exists
+func (s *Service) activeSession(refreshToken string) (*models.Session, error) {
+	if refreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+
+	var session models.Session
+	err := s.db.Joins("JOIN users ON users.id = sessions.user_id AND users.deleted_at IS NULL").
+		Where("sessions.refresh_token_hash = ? AND sessions.revoked_at IS NULL AND sessions.expires_at > ?",
+			utils.HashToken(s.config.Secret, refreshToken), time.Now()).
+		First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired refresh token")
+		}
+		log.Error().Err(err).Msg("Failed to get session")
+		return nil, status.Error(codes.Internal, "failed to get session")
+	}
+
+	return &session, nil
+}
+
+// tokenResponse issues an access token for the session
+func (s *Service) tokenResponse(session *models.Session, refreshToken string) (*aliasme.TokenResponse, error) {
+	expiresAt := time.Now().Add(s.config.AccessTokenTTL)
+	accessToken, err := s.signAccessToken(session.UserID, session.ID, expiresAt)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sign access token")
+		return nil, status.Error(codes.Internal, "failed to issue access token")
+	}
+
+	return &aliasme.TokenResponse{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  timestamppb.New(expiresAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(session.ExpiresAt),
+		TokenType:             tokenType,
+	}, nil
+}
+
+// generateSecret generates an opaque random token
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+var (
+	dummyHashOnce  sync.Once
+	dummyHashValue string
+)
+
+// dummyHash returns a bcrypt hash compared against when the user does not
+// exist
+func dummyHash() string {
+	dummyHashOnce.Do(func() {
+		hash, _ := bcrypt.GenerateFromPassword([]byte(xid.New().String()), bcrypt.DefaultCost)
+		dummyHashValue = string(hash)
+	})
+
+	return dummyHashValue
+}
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/database"
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/xid"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// testPassword is the password of the users created by createUser
+const testPassword = "correct horse battery"
+
+// recordingMailer keeps the password reset tokens instead of emailing them
+type recordingMailer struct {
+	tokens []string
+}
+
+func (m *recordingMailer) SendPasswordReset(tx *gorm.DB, to, token, locale string, ttl time.Duration) error {
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func newTestService(t *testing.T) (*Service, *recordingMailer) {
+	t.Helper()
+
+	db, err := database.New(&database.Config{Path: filepath.Join(t.TempDir(), "aliasme.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mailer := &recordingMailer{}
+
+	return New(db, Config{
+		Secret:          []byte("test secret"),
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+		Password:        PasswordPolicy{MinLength: 12, RequireDigit: true},
+		ResetTokenTTL:   time.Hour,
+		TOTPIssuer:      "aliasme",
+		TOTPMaxFailures: 3,
+		TOTPLockout:     time.Minute,
+		DeviceCodeTTL:   time.Minute,
+	}, mailer), mailer
+}
+
+// createUser creates a user with testPassword
+func createUser(t *testing.T, s *Service, username string) *models.User {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{
+		ID:       xid.New().String(),
+		Username: username,
+		Email:    username + "@example.org",
+		Password: string(hash),
+		Role:     models.RoleUser,
+	}
+	if err := s.db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+// assertCode fails the test unless err has the code
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != want {
+		t.Fatalf("error = %v, want %s", err, want)
+	}
+}
+
+func TestRefreshRotatesRefreshToken(t *testing.T) {
+	s, _ := newTestService(t)
+	user := createUser(t, s, "alice")
+
+	opened, err := s.OpenSession(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshed, err := s.Refresh(context.Background(), &aliasme.RefreshRequest{RefreshToken: opened.RefreshToken})
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if refreshed.RefreshToken == opened.RefreshToken {
+		t.Fatal("refresh token was not rotated")
+	}
+	identity, err := s.AuthenticateSession(refreshed.AccessToken)
+	if err != nil {
+		t.Fatalf("new access token: %v", err)
+	}
+	if identity.UserID != user.ID {
+		t.Fatalf("identity user = %s, want %s", identity.UserID, user.ID)
+	}
+
+	// The rotated token keeps working
+	if _, err := s.Refresh(context.Background(), &aliasme.RefreshRequest{RefreshToken: refreshed.RefreshToken}); err != nil {
+		t.Fatalf("Refresh with the rotated token: %v", err)
+	}
+}
+
+func TestRefreshRefusesUnusableTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		// spoil makes the refresh token of the session unusable
+		spoil func(t *testing.T, s *Service, tokens *aliasme.TokenResponse) string
+	}{
+		{"reused", func(t *testing.T, s *Service, tokens *aliasme.TokenResponse) string {
+			if _, err := s.Refresh(context.Background(), &aliasme.RefreshRequest{RefreshToken: tokens.RefreshToken}); err != nil {
+				t.Fatal(err)
+			}
+			return tokens.RefreshToken
+		}},
+		{"logged out", func(t *testing.T, s *Service, tokens *aliasme.TokenResponse) string {
+			if _, err := s.Logout(context.Background(), &aliasme.LogoutRequest{RefreshToken: tokens.RefreshToken}); err != nil {
+				t.Fatal(err)
+			}
+			return tokens.RefreshToken
+		}},
+		{"revoked", func(t *testing.T, s *Service, tokens *aliasme.TokenResponse) string {
+			if err := s.db.Model(&models.Session{}).Where("1 = 1").Update("revoked_at", time.Now()).Error; err != nil {
+				t.Fatal(err)
+			}
+			return tokens.RefreshToken
+		}},
+		{"expired", func(t *testing.T, s *Service, tokens *aliasme.TokenResponse) string {
+			if err := s.db.Model(&models.Session{}).Where("1 = 1").Update("expires_at", time.Now().Add(-time.Second)).Error; err != nil {
+				t.Fatal(err)
+			}
+			return tokens.RefreshToken
+		}},
+		{"unknown", func(t *testing.T, s *Service, tokens *aliasme.TokenResponse) string {
+			return tokens.RefreshToken + "x"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestService(t)
+			user := createUser(t, s, "alice")
+			tokens, err := s.OpenSession(user.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token := tt.spoil(t, s, tokens)
+			_, err = s.Refresh(context.Background(), &aliasme.RefreshRequest{RefreshToken: token})
+			assertCode(t, err, codes.Unauthenticated)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/utils"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// Scopes of personal access tokens
+const (
+	ScopeAliasesRead  = "aliases:read"
+	ScopeAliasesWrite = "aliases:write"
+	ScopeEmailsWrite  = "emails:write"
+	ScopeAdmin        = "admin"
+)
+
+// tokenPrefix tells personal access tokens apart from access tokens
+const tokenPrefix = "amp_"
+
+// lastUsedResolution bounds how often the last use of a token is recorded
+const lastUsedResolution = time.Minute
+
+var validScopes = map[string]bool{
+	ScopeAliasesRead:  true,
+	ScopeAliasesWrite: true,
+	ScopeEmailsWrite:  true,
+	ScopeAdmin:        true,
+}
+
+// methodScopes maps the RPCs available to personal access tokens to the scope
+// they require. Admin RPCs require ScopeAdmin; account management, including
+// tokens themselves, needs a login session.
+var methodScopes = map[string]string{
+	aliasme.EmailService_ListAliases_FullMethodName:        ScopeAliasesRead,
+	aliasme.EmailService_ListDeletedAliases_FullMethodName: ScopeAliasesRead,
+	aliasme.EmailService_GetUsage_FullMethodName:           ScopeAliasesRead,
+	aliasme.EmailService_CreateAlias_FullMethodName:        ScopeAliasesWrite,
+	aliasme.EmailService_QuickCreateAlias_FullMethodName:   ScopeAliasesWrite,
+	aliasme.EmailService_UpdateAlias_FullMethodName:        ScopeAliasesWrite,
+	aliasme.EmailService_DeleteAlias_FullMethodName:        ScopeAliasesWrite,
+	aliasme.EmailService_UndeleteAlias_FullMethodName:      ScopeAliasesWrite,
+	aliasme.EmailService_RegisterEmail_FullMethodName:      ScopeEmailsWrite,
+	aliasme.EmailService_ResendVerification_FullMethodName: ScopeEmailsWrite,
+	aliasme.EmailService_SetPGPKey_FullMethodName:          ScopeEmailsWrite,
+	aliasme.EmailService_RemovePGPKey_FullMethodName:       ScopeEmailsWrite,
+}
+
+// requiredScope returns the scope a personal access token needs to call the
+// method, if it may call it at all
+func requiredScope(method string) (string, bool) {
+	if scope, ok := methodScopes[method]; ok {
+		return scope, true
+	}
+	if strings.HasPrefix(method, "/"+aliasme.AdminService_ServiceDesc.ServiceName+"/") {
+		return ScopeAdmin, true
+	}
+
+	return "", false
+}
+
+// CreateToken creates a personal access token for the authenticated user
+func (s *Service) CreateToken(ctx context.Context, req *aliasme.CreateTokenRequest) (*aliasme.CreateTokenResponse, error) {
+	userID, err := ActingUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if hasScope(scopes, ScopeAdmin) {
+		if err := RequireAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		t := req.ExpiresAt.AsTime()
+		if !t.After(now) {
+			return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+		}
+		expiresAt = &t
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate token")
+		return nil, status.Error(codes.Internal, "failed to create token")
+	}
+	token := tokenPrefix + secret
+
+	pat := &models.PersonalAccessToken{
+		ID:        xid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		TokenHash: utils.HashToken(s.config.Secret, token),
+		Scopes:    strings.Join(scopes, " "),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	if err := s.db.Create(pat).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to create token")
+		return nil, status.Error(codes.Internal, "failed to create token")
+	}
+
+	return &aliasme.CreateTokenResponse{Token: token, Info: tokenToProto(pat)}, nil
+}
+
+// ListTokens lists the personal access tokens of the authenticated user,
+// including expired and revoked ones
+func (s *Service) ListTokens(ctx context.Context, req *aliasme.ListTokensRequest) (*aliasme.ListTokensResponse, error) {
+	userID, err := ActingUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var pats []models.PersonalAccessToken
+	if err := s.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&pats).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to list tokens")
+		return nil, status.Error(codes.Internal, "failed to list tokens")
+	}
+
+	tokens := make([]*aliasme.PersonalAccessToken, len(pats))
+	for i := range pats {
+		tokens[i] = tokenToProto(&pats[i])
+	}
+
+	return &aliasme.ListTokensResponse{Tokens: tokens}, nil
+}
+
+// RevokeToken revokes a personal access token of the authenticated user
+func (s *Service) RevokeToken(ctx context.Context, req *aliasme.RevokeTokenRequest) (*aliasme.RevokeTokenResponse, error) {
+	userID, err := ActingUser(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var pat models.PersonalAccessToken
+	if err := s.db.First(&pat, "id = ? AND user_id = ?", req.Id, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "token not found")
+		}
+		log.Error().Err(err).Msg("Failed to get token")
+		return nil, status.Error(codes.Internal, "failed to get token")
+	}
+
+	if pat.RevokedAt == nil {
+		if err := s.db.Model(&pat).Update("revoked_at", time.Now()).Error; err != nil {
+			log.Error().Err(err).Msg("Failed to revoke token")
+			return nil, status.Error(codes.Internal, "failed to revoke token")
+		}
+	}
+
+	return &aliasme.RevokeTokenResponse{Success: true}, nil
+}
+
+// authenticateToken verifies a personal access token and its scopes for the
+// method and returns the context carrying the token identity
+func (s *Service) authenticateToken(ctx context.Context, method, token string) (context.Context, error) {
+	now := time.Now()
+
+	var pat models.PersonalAccessToken
+	err := s.db.Where("token_hash = ? AND revoked_at IS NULL", utils.HashToken(s.config.Secret, token)).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		First(&pat).Error
+	var user models.User
+	if err == nil {
+		err = s.db.Select("id", "role").First(&user, "id = ?", pat.UserID).Error
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "invalid, expired or revoked token")
+		}
+		log.Error().Err(err).Msg("Failed to get token")
+		return nil, status.Error(codes.Internal, "failed to get token")
+	}
+
+	scopes := strings.Fields(pat.Scopes)
+	scope, ok := requiredScope(method)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "personal access tokens cannot call this method")
+	}
+	if !hasScope(scopes, scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "token lacks the %s scope", scope)
+	}
+
+	// Record the use, at most once per resolution period
+	err = s.db.Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", pat.ID, now.Add(-lastUsedResolution)).
+		UpdateColumn("last_used_at", now).Error
+	if err != nil {
+		log.Error().Err(err).Str("token_id", pat.ID).Msg("Failed to record token use")
+	}
+
+	return WithIdentity(ctx, &Identity{UserID: user.ID, TokenID: pat.ID, Role: user.Role, Scopes: scopes}), nil
+}
+
+// normalizeScopes validates the requested scopes and returns them sorted
+// without duplicates
+func normalizeScopes(requested []string) ([]string, error) {
+	seen := make(map[string]bool, len(requested))
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !validScopes[scope] {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown scope %q", scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one scope is required")
+	}
+	sort.Strings(scopes)
+
+	return scopes, nil
+}
+
+// hasScope reports whether scope is among scopes
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tokenToProto converts a personal access token to its protobuf message
+func tokenToProto(pat *models.PersonalAccessToken) *aliasme.PersonalAccessToken {
+	token := &aliasme.PersonalAccessToken{
+		Id:        pat.ID,
+		Name:      pat.Name,
+		Scopes:    strings.Fields(pat.Scopes),
+		CreatedAt: timestamppb.New(pat.CreatedAt),
+	}
+	if pat.ExpiresAt != nil {
+		token.ExpiresAt = timestamppb.New(*pat.ExpiresAt)
+	}
+	if pat.LastUsedAt != nil {
+		token.LastUsedAt = timestamppb.New(*pat.LastUsedAt)
+	}
+	if pat.RevokedAt != nil {
+		token.RevokedAt = timestamppb.New(*pat.RevokedAt)
+	}
+
+	return token
+}
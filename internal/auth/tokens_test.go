@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// allScopes lists every scope
+var allScopes = []string{ScopeAliasesRead, ScopeAliasesWrite, ScopeEmailsWrite, ScopeAdmin}
+
+// createToken creates a personal access token of the user with the scopes
+func createToken(t *testing.T, s *Service, user *models.User, scopes ...string) (string, *aliasme.PersonalAccessToken) {
+	t.Helper()
+
+	ctx := WithIdentity(context.Background(), &Identity{UserID: user.ID, SessionID: "session", Role: user.Role})
+	created, err := s.CreateToken(ctx, &aliasme.CreateTokenRequest{Name: "test", Scopes: scopes})
+	if err != nil {
+		t.Fatalf("CreateToken(%v): %v", scopes, err)
+	}
+
+	return created.Token, created.Info
+}
+
+// without returns the scopes but one
+func without(scope string) []string {
+	var scopes []string
+	for _, s := range allScopes {
+		if s != scope {
+			scopes = append(scopes, s)
+		}
+	}
+
+	return scopes
+}
+
+func TestTokensNeedTheScopeOfTheMethod(t *testing.T) {
+	s, _ := newTestService(t)
+	admin := createUser(t, s, "admin")
+	if err := s.db.Model(admin).Update("role", models.RoleAdmin).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	only := map[string]string{}
+	lacking := map[string]string{}
+	for _, scope := range allScopes {
+		only[scope], _ = createToken(t, s, admin, scope)
+		lacking[scope], _ = createToken(t, s, admin, without(scope)...)
+	}
+	full, _ := createToken(t, s, admin, allScopes...)
+
+	families := map[string]bool{}
+	for _, r := range allRPCs() {
+		if publicMethods[r.method] {
+			continue
+		}
+
+		scope, ok := requiredScope(r.method)
+		if !ok {
+			// Account management needs a login session
+			_, called, err := call(withAuthorization("Bearer "+full), s, r)
+			if called {
+				t.Fatalf("%s: reached with a personal access token", r.method)
+			}
+			assertCode(t, err, codes.PermissionDenied)
+			continue
+		}
+		families[scope] = true
+
+		_, called, err := call(withAuthorization("Bearer "+lacking[scope]), s, r)
+		if called {
+			t.Fatalf("%s: reached without the %s scope", r.method, scope)
+		}
+		assertCode(t, err, codes.PermissionDenied)
+
+		identity, called, err := call(withAuthorization("Bearer "+only[scope]), s, r)
+		if err != nil || !called {
+			t.Fatalf("%s with the %s scope: called = %v, error = %v", r.method, scope, called, err)
+		}
+		if identity == nil || identity.UserID != admin.ID || identity.TokenID == "" {
+			t.Fatalf("%s: identity = %+v, want the token of admin", r.method, identity)
+		}
+	}
+	for _, scope := range allScopes {
+		if !families[scope] {
+			t.Fatalf("no method requires the %s scope", scope)
+		}
+	}
+}
+
+func TestAdminScopeNeedsAnAdmin(t *testing.T) {
+	s, _ := newTestService(t)
+	alice := createUser(t, s, "alice")
+
+	ctx := WithIdentity(context.Background(), &Identity{UserID: alice.ID, SessionID: "session", Role: alice.Role})
+	_, err := s.CreateToken(ctx, &aliasme.CreateTokenRequest{Name: "test", Scopes: []string{ScopeAdmin}})
+	assertCode(t, err, codes.PermissionDenied)
+
+	// Admin tokens of demoted users lose access
+	if err := s.db.Model(alice).Update("role", models.RoleAdmin).Error; err != nil {
+		t.Fatal(err)
+	}
+	token, _ := createToken(t, s, alice, ScopeAdmin)
+	if err := s.db.Model(alice).Update("role", models.RoleUser).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	_, called, err := call(withAuthorization("Bearer "+token), s, rpc{method: aliasme.AdminService_ListMailQueue_FullMethodName})
+	if err != nil || !called {
+		t.Fatalf("called = %v, error = %v", called, err)
+	}
+	if err := RequireAdmin(ctxWithToken(t, s, token)); err == nil {
+		t.Fatal("demoted user kept admin rights")
+	}
+}
+
+// ctxWithToken returns the context the interceptor gives to the handlers of
+// the token
+func ctxWithToken(t *testing.T, s *Service, token string) context.Context {
+	t.Helper()
+
+	ctx, err := s.authenticate(withAuthorization("Bearer "+token), aliasme.AdminService_ListMailQueue_FullMethodName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ctx
+}
+
+func TestRevokedAndExpiredTokensAreRefused(t *testing.T) {
+	s, _ := newTestService(t)
+	alice := createUser(t, s, "alice")
+	method := rpc{method: aliasme.EmailService_ListAliases_FullMethodName}
+	ctx := WithIdentity(context.Background(), &Identity{UserID: alice.ID, SessionID: "session", Role: alice.Role})
+
+	revoked, info := createToken(t, s, alice, ScopeAliasesRead)
+	if _, err := s.RevokeToken(ctx, &aliasme.RevokeTokenRequest{Id: info.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := s.CreateToken(ctx, &aliasme.CreateTokenRequest{
+		Name:      "expiring",
+		Scopes:    []string{ScopeAliasesRead},
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, called, err := call(withAuthorization("Bearer "+created.Token), s, method); err != nil || !called {
+		t.Fatalf("before expiry: called = %v, error = %v", called, err)
+	}
+	err = s.db.Model(&models.PersonalAccessToken{}).Where("id = ?", created.Info.Id).
+		Update("expires_at", time.Now().Add(-time.Second)).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing the password revokes every token
+	changed, _ := createToken(t, s, alice, ScopeAliasesRead)
+	_, err = s.ChangePassword(ctx, &aliasme.ChangePasswordRequest{CurrentPassword: testPassword, NewPassword: "another password 1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, token := range map[string]string{"revoked": revoked, "expired": created.Token, "password changed": changed} {
+		_, called, err := call(withAuthorization("Bearer "+token), s, method)
+		if called {
+			t.Fatalf("%s token reached the handler", name)
+		}
+		assertCode(t, err, codes.Unauthenticated)
+	}
+}
+
+func TestTokenUseIsRecorded(t *testing.T) {
+	s, _ := newTestService(t)
+	alice := createUser(t, s, "alice")
+	token, info := createToken(t, s, alice, ScopeAliasesRead)
+	method := rpc{method: aliasme.EmailService_ListAliases_FullMethodName}
+
+	lastUsed := func() *time.Time {
+		t.Helper()
+		var pat models.PersonalAccessToken
+		if err := s.db.First(&pat, "id = ?", info.Id).Error; err != nil {
+			t.Fatal(err)
+		}
+		return pat.LastUsedAt
+	}
+	if lastUsed() != nil {
+		t.Fatal("unused token has a last use")
+	}
+
+	if _, _, err := call(withAuthorization("Bearer "+token), s, method); err != nil {
+		t.Fatal(err)
+	}
+	first := lastUsed()
+	if first == nil || time.Since(*first) > time.Minute {
+		t.Fatalf("last use = %v, want now", first)
+	}
+
+	// Recorded at most once per resolution period
+	if _, _, err := call(withAuthorization("Bearer "+token), s, method); err != nil {
+		t.Fatal(err)
+	}
+	if again := lastUsed(); again == nil || !again.Equal(*first) {
+		t.Fatalf("last use = %v, want %v", again, first)
+	}
+
+	past := time.Now().Add(-2 * lastUsedResolution)
+	if err := s.db.Model(&models.PersonalAccessToken{}).Where("id = ?", info.Id).Update("last_used_at", past).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := call(withAuthorization("Bearer "+token), s, method); err != nil {
+		t.Fatal(err)
+	}
+	if later := lastUsed(); later == nil || !later.After(past) {
+		t.Fatalf("last use = %v, want after %v", later, past)
+	}
+}
@@ -0,0 +1,421 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/utils"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// TOTP parameters (RFC 6238), the defaults every authenticator supports
+const (
+	totpPeriod     = 30
+	totpDigits     = 6
+	totpSecretSize = 20
+	// totpSkew is the number of time steps accepted on each side of the
+	// current one, for clock drift
+	totpSkew = 1
+)
+
+// Recovery codes are recoveryCodeCount codes of 10 base32 characters
+const (
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EnrollTOTP generates a new TOTP secret for the authenticated user. Until it
+// is confirmed, logging in does not require a code.
+func (s *Service) EnrollTOTP(ctx context.Context, req *aliasme.EnrollTOTPRequest) (*aliasme.EnrollTOTPResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		log.Error().Err(err).Msg("Failed to generate TOTP secret")
+		return nil, status.Error(codes.Internal, "failed to enroll TOTP")
+	}
+	sealed, err := s.sealTOTPSecret(secret)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encrypt TOTP secret")
+		return nil, status.Error(codes.Internal, "failed to enroll TOTP")
+	}
+	if err := s.db.Model(user).Update("totp_secret", sealed).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to save TOTP secret")
+		return nil, status.Error(codes.Internal, "failed to enroll TOTP")
+	}
+
+	encoded := totpEncoding.EncodeToString(secret)
+	return &aliasme.EnrollTOTPResponse{
+		Secret:     encoded,
+		OtpauthUri: s.otpauthURI(user.Username, encoded),
+	}, nil
+}
+
+// ConfirmTOTP enables two-factor authentication once the user proves their
+// authenticator works, and returns fresh recovery codes
+func (s *Service) ConfirmTOTP(ctx context.Context, req *aliasme.ConfirmTOTPRequest) (*aliasme.ConfirmTOTPResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return nil, status.Error(codes.FailedPrecondition, "no pending TOTP enrolment")
+	}
+
+	secret, err := s.openTOTPSecret(user.TOTPSecret)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to decrypt TOTP secret")
+		return nil, status.Error(codes.Internal, "failed to confirm TOTP")
+	}
+	step, ok := matchTOTP(secret, strings.TrimSpace(req.Code), time.Now())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid TOTP code")
+	}
+
+	recoveryCodes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate recovery codes")
+		return nil, status.Error(codes.Internal, "failed to confirm TOTP")
+	}
+
+	// The secret is checked too, in case a concurrent enrolment replaced it
+	result := s.db.Model(&models.User{}).
+		Where("id = ? AND totp_secret = ? AND totp_enabled = ?", user.ID, user.TOTPSecret, false).
+		Updates(map[string]any{
+			"totp_enabled":   true,
+			"totp_last_step": step,
+			"recovery_codes": hashes,
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Failed to enable TOTP")
+		return nil, status.Error(codes.Internal, "failed to confirm TOTP")
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Error(codes.Aborted, "TOTP enrolment changed, enroll again")
+	}
+
+	return &aliasme.ConfirmTOTPResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableTOTP disables two-factor authentication of the authenticated user
+func (s *Service) DisableTOTP(ctx context.Context, req *aliasme.DisableTOTPRequest) (*aliasme.DisableTOTPResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	ok, err := s.verifySecondFactor(user, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "invalid two-factor code")
+	}
+
+	if err := ClearTOTP(s.db, user.ID); err != nil {
+		log.Error().Err(err).Msg("Failed to disable TOTP")
+		return nil, status.Error(codes.Internal, "failed to disable TOTP")
+	}
+
+	return &aliasme.DisableTOTPResponse{Success: true}, nil
+}
+
+// ClearTOTP removes the TOTP secret and recovery codes of a user, disabling
+// two-factor authentication. It reports gorm.ErrRecordNotFound when the user
+// does not exist.
+func ClearTOTP(db *gorm.DB, userID string) error {
+	result := db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"totp_secret":       "",
+			"totp_enabled":      false,
+			"totp_last_step":    0,
+			"totp_failures":     0,
+			"totp_locked_until": nil,
+			"recovery_codes":    "",
+			"updated_at":        time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// maxTOTPLockout caps the lockout of second factor checks
+const maxTOTPLockout = 24 * time.Hour
+
+// verifySecondFactor checks a TOTP or recovery code of a user with two-factor
+// authentication enabled, counting invalid codes. Past the limit, checks are
+// refused until the lockout ends.
+func (s *Service) verifySecondFactor(user *models.User, code string) (bool, error) {
+	now := time.Now()
+	if user.TOTPLockedUntil != nil && now.Before(*user.TOTPLockedUntil) {
+		return false, status.Error(codes.ResourceExhausted, "too many invalid two-factor codes, retry later")
+	}
+
+	ok, err := s.checkSecondFactor(user, code)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to check two-factor code")
+		return false, status.Error(codes.Internal, "failed to check two-factor code")
+	}
+
+	if ok {
+		if user.TOTPFailures > 0 {
+			err = s.db.Model(&models.User{}).
+				Where("id = ?", user.ID).
+				UpdateColumns(map[string]any{"totp_failures": 0, "totp_locked_until": nil}).Error
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to reset two-factor failures")
+			}
+		}
+		return true, nil
+	}
+
+	if err := s.recordTOTPFailure(user.ID, now); err != nil {
+		log.Error().Err(err).Msg("Failed to record two-factor failure")
+		return false, status.Error(codes.Internal, "failed to check two-factor code")
+	}
+
+	return false, nil
+}
+
+// recordTOTPFailure counts an invalid two-factor code of a user and locks
+// their checks once the limit is reached
+func (s *Service) recordTOTPFailure(userID string, now time.Time) error {
+	err := s.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		UpdateColumn("totp_failures", gorm.Expr("totp_failures + ?", 1)).Error
+	if err != nil {
+		return err
+	}
+	if s.config.TOTPMaxFailures <= 0 {
+		return nil
+	}
+
+	var user models.User
+	if err := s.db.Select("totp_failures").First(&user, "id = ?", userID).Error; err != nil {
+		return err
+	}
+	over := user.TOTPFailures - s.config.TOTPMaxFailures
+	if over < 0 {
+		return nil
+	}
+
+	lockout := s.config.TOTPLockout
+	for i := 0; i < over && lockout < maxTOTPLockout; i++ {
+		lockout *= 2
+	}
+	lockout = min(lockout, maxTOTPLockout)
+	log.Warn().Str("user_id", userID).Int("failures", user.TOTPFailures).Dur("lockout", lockout).Msg("Two-factor checks locked")
+
+	return s.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		UpdateColumn("totp_locked_until", now.Add(lockout)).Error
+}
+
+// checkSecondFactor verifies a TOTP or recovery code of a user with
+// two-factor authentication enabled. Accepted codes cannot be used again.
+func (s *Service) checkSecondFactor(user *models.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return false, nil
+	}
+
+	if len(code) == totpDigits {
+		secret, err := s.openTOTPSecret(user.TOTPSecret)
+		if err != nil {
+			return false, err
+		}
+		step, ok := matchTOTP(secret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+
+		// Concurrent logins with the same code: only one uses it
+		result := s.db.Model(&models.User{}).
+			Where("id = ? AND totp_last_step < ?", user.ID, step).
+			UpdateColumn("totp_last_step", step)
+		return result.RowsAffected > 0, result.Error
+	}
+
+	hash := utils.HashToken(s.config.Secret, normalizeRecoveryCode(code))
+	hashes := strings.Fields(user.RecoveryCodes)
+	for i, h := range hashes {
+		if !hmac.Equal([]byte(h), []byte(hash)) {
+			continue
+		}
+
+		remaining := strings.Join(append(hashes[:i:i], hashes[i+1:]...), " ")
+		result := s.db.Model(&models.User{}).
+			Where("id = ? AND recovery_codes = ?", user.ID, user.RecoveryCodes).
+			UpdateColumn("recovery_codes", remaining)
+		if result.Error == nil && result.RowsAffected > 0 {
+			log.Info().Str("user_id", user.ID).Int("remaining", len(hashes)-1).Msg("Recovery code used")
+		}
+		return result.RowsAffected > 0, result.Error
+	}
+
+	return false, nil
+}
+
+// currentUser returns the user of the login session
+func (s *Service) currentUser(ctx context.Context) (*models.User, error) {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	var user models.User
+	if err := s.db.First(&user, "id = ?", identity.UserID).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to get user")
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+
+	return &user, nil
+}
+
+// otpauthURI returns the key URI authenticators import, usually as a QR code
+func (s *Service) otpauthURI(account, secret string) string {
+	issuer := s.config.TOTPIssuer
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}).String()
+}
+
+// generateRecoveryCodes returns new recovery codes and their space-separated
+// hashes
+func (s *Service) generateRecoveryCodes() ([]string, string, error) {
+	plain := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range plain {
+		b := make([]byte, recoveryCodeSize*5/8)
+		if _, err := rand.Read(b); err != nil {
+			return nil, "", err
+		}
+		code := strings.ToLower(totpEncoding.EncodeToString(b))
+		plain[i] = code[:recoveryCodeSize/2] + "-" + code[recoveryCodeSize/2:]
+		hashes[i] = utils.HashToken(s.config.Secret, code)
+	}
+
+	return plain, strings.Join(hashes, " "), nil
+}
+
+// normalizeRecoveryCode strips the separators and case of a typed recovery
+// code
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// sealTOTPSecret encrypts a TOTP secret with a key derived from the server
+// secret, so database read access is not enough to generate codes
+func (s *Service) sealTOTPSecret(secret []byte) (string, error) {
+	aead, err := s.totpAEAD()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.RawStdEncoding.EncodeToString(aead.Seal(nonce, nonce, secret, nil)), nil
+}
+
+// openTOTPSecret decrypts a TOTP secret sealed by sealTOTPSecret
+func (s *Service) openTOTPSecret(sealed string) ([]byte, error) {
+	aead, err := s.totpAEAD()
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("sealed TOTP secret is too short")
+	}
+
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+}
+
+// totpAEAD returns the cipher TOTP secrets are encrypted with
+func (s *Service) totpAEAD() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(utils.DeriveKey(s.config.Secret, "totp-secret"))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// matchTOTP returns the time step around now the code is valid for
+func matchTOTP(secret []byte, code string, now time.Time) (int64, bool) {
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if hmac.Equal([]byte(totpCode(secret, step)), []byte(code)) {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// totpCode computes the code of a time step (RFC 4226 and RFC 6238)
+func totpCode(secret []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
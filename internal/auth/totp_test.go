@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"google.golang.org/grpc/codes"
+)
+
+// enableTOTP enables two-factor authentication of the user and returns its
+// secret and recovery codes
+func enableTOTP(t *testing.T, s *Service, user *models.User) ([]byte, []string) {
+	t.Helper()
+
+	secret := []byte("12345678901234567890")
+	sealed, err := s.sealTOTPSecret(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recoveryCodes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.db.Model(user).Updates(map[string]any{
+		"totp_secret":    sealed,
+		"totp_enabled":   true,
+		"recovery_codes": hashes,
+	}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return secret, recoveryCodes
+}
+
+// currentCode returns the TOTP code of the current time step
+func currentCode(secret []byte) string {
+	return totpCode(secret, time.Now().Unix()/totpPeriod)
+}
+
+func TestTOTPCodeMatchesRFC6238(t *testing.T) {
+	// SHA-1 test vectors of RFC 6238 appendix B, truncated to 6 digits
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		if got := totpCode(secret, tt.unix/totpPeriod); got != tt.want {
+			t.Fatalf("code at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestMatchTOTPAcceptsOneStepOfSkew(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / totpPeriod
+
+	for offset := int64(-2); offset <= 2; offset++ {
+		step, ok := matchTOTP(secret, totpCode(secret, current+offset), now)
+		want := offset >= -totpSkew && offset <= totpSkew
+		if ok != want {
+			t.Fatalf("offset %d: accepted = %v, want %v", offset, ok, want)
+		}
+		if ok && step != current+offset {
+			t.Fatalf("offset %d: step = %d, want %d", offset, step, current+offset)
+		}
+	}
+}
+
+func TestTOTPCodeCannotBeReplayed(t *testing.T) {
+	s, _ := newTestService(t)
+	user := createUser(t, s, "alice")
+	secret, _ := enableTOTP(t, s, user)
+
+	code := currentCode(secret)
+	if _, err := s.VerifyLogin("alice", testPassword, code); err != nil {
+		t.Fatalf("first login: %v", err)
+	}
+	_, err := s.VerifyLogin("alice", testPassword, code)
+	assertCode(t, err, codes.Unauthenticated)
+
+	// Nor can the code of an earlier step
+	previous := totpCode(secret, time.Now().Unix()/totpPeriod-1)
+	_, err = s.VerifyLogin("alice", testPassword, previous)
+	assertCode(t, err, codes.Unauthenticated)
+}
+
+func TestRecoveryCodesAreSingleUse(t *testing.T) {
+	s, _ := newTestService(t)
+	user := createUser(t, s, "alice")
+	_, recoveryCodes := enableTOTP(t, s, user)
+
+	// Typed with another case and without the separator
+	typed := strings.ToUpper(strings.ReplaceAll(recoveryCodes[0], "-", ""))
+	if _, err := s.VerifyLogin("alice", testPassword, typed); err != nil {
+		t.Fatalf("login with a recovery code: %v", err)
+	}
+	_, err := s.VerifyLogin("alice", testPassword, recoveryCodes[0])
+	assertCode(t, err, codes.Unauthenticated)
+
+	// The other codes still work
+	if _, err := s.VerifyLogin("alice", testPassword, recoveryCodes[1]); err != nil {
+		t.Fatalf("login with another recovery code: %v", err)
+	}
+
+	var stored models.User
+	if err := s.db.First(&stored, "id = ?", user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if remaining := len(strings.Fields(stored.RecoveryCodes)); remaining != recoveryCodeCount-2 {
+		t.Fatalf("%d recovery codes left, want %d", remaining, recoveryCodeCount-2)
+	}
+}
+
+func TestTOTPLocksAfterRepeatedFailures(t *testing.T) {
+	s, _ := newTestService(t)
+	user := createUser(t, s, "alice")
+	secret, _ := enableTOTP(t, s, user)
+
+	for i := 0; i < s.config.TOTPMaxFailures; i++ {
+		_, err := s.VerifyLogin("alice", testPassword, "000000x")
+		assertCode(t, err, codes.Unauthenticated)
+	}
+
+	var stored models.User
+	if err := s.db.First(&stored, "id = ?", user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.TOTPLockedUntil == nil {
+		t.Fatal("checks not locked after the failure limit")
+	}
+	if lockout := time.Until(*stored.TOTPLockedUntil); lockout <= 0 || lockout > s.config.TOTPLockout {
+		t.Fatalf("locked for %s, want up to %s", lockout, s.config.TOTPLockout)
+	}
+
+	// Even a valid code is refused while locked, without being used up
+	code := currentCode(secret)
+	_, err := s.VerifyLogin("alice", testPassword, code)
+	assertCode(t, err, codes.ResourceExhausted)
+
+	// Once the lockout is over, the valid code unlocks and resets the count
+	if err := s.db.Model(user).Update("totp_locked_until", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.VerifyLogin("alice", testPassword, code); err != nil {
+		t.Fatalf("login after the lockout: %v", err)
+	}
+	var unlocked models.User
+	if err := s.db.First(&unlocked, "id = ?", user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if unlocked.TOTPFailures != 0 || unlocked.TOTPLockedUntil != nil {
+		t.Fatalf("failures = %d, locked until %v, want reset", unlocked.TOTPFailures, unlocked.TOTPLockedUntil)
+	}
+}
+
+func TestTOTPLockoutDoublesPastTheLimit(t *testing.T) {
+	s, _ := newTestService(t)
+	user := createUser(t, s, "alice")
+	enableTOTP(t, s, user)
+
+	now := time.Now()
+	for i := 0; i < s.config.TOTPMaxFailures+2; i++ {
+		if err := s.recordTOTPFailure(user.ID, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stored models.User
+	if err := s.db.First(&stored, "id = ?", user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(4 * s.config.TOTPLockout); stored.TOTPLockedUntil == nil || !stored.TOTPLockedUntil.Equal(want) {
+		t.Fatalf("locked until %v, want %v", stored.TOTPLockedUntil, want)
+	}
+}
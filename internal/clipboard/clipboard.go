@@ -0,0 +1,62 @@
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Writer copies text to a clipboard
+type Writer interface {
+	WriteText(text string) error
+}
+
+// WriterFunc adapts a function to the Writer interface
+type WriterFunc func(text string) error
+
+// WriteText calls f(text)
+func (f WriterFunc) WriteText(text string) error {
+	return f(text)
+}
+
+// System returns a Writer backed by the platform clipboard utility
+func System() Writer {
+	return WriterFunc(writeSystem)
+}
+
+// writeSystem pipes text to the first clipboard utility found on the host
+func writeSystem(text string) error {
+	for _, args := range commands() {
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+
+		cmd := exec.Command(path, args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to run %s: %w", args[0], err)
+		}
+		return nil
+	}
+
+	return errors.New("no clipboard utility found")
+}
+
+// commands lists the clipboard utilities to try for the current platform
+func commands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	default:
+		return [][]string{
+			{"wl-copy"},
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+		}
+	}
+}
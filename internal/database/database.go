@@ -14,17 +14,36 @@ type Config struct {
 
 // New creates a new database connection
 func New(cfg *Config) (*gorm.DB, error) {
-	db, err := gorm.Open(sqlite.Open(cfg.Path), &gorm.Config{})
+	// Errors are translated, so unique violations are gorm.ErrDuplicatedKey
+	db, err := gorm.Open(sqlite.Open(cfg.Path), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
 
 	// Auto migrate the schema
-	err = db.AutoMigrate(&models.User{}, &models.Email{}, &models.Alias{})
+	err = db.AutoMigrate(
+		&models.User{},
+		&models.Email{},
+		&models.Alias{},
+		&models.QuarantinedAddress{},
+		&models.OutgoingMail{},
+		&models.Notification{},
+		&models.Session{},
+		&models.PersonalAccessToken{},
+		&models.PasswordReset{},
+		&models.WebAuthnCredential{},
+		&models.WebAuthnChallenge{},
+		&models.DeviceLogin{},
+	)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to migrate database")
 		return nil, err
 	}
 
+	if err := migrate(db); err != nil {
+		log.Error().Err(err).Msg("Failed to migrate database")
+		return nil, err
+	}
+
 	return db, nil
 }
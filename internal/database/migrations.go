@@ -0,0 +1,65 @@
+package database
+
+import (
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// plaintextVerificationColumns held verification tokens and codes in clear
+// before only their hashes were stored
+var plaintextVerificationColumns = []string{"token", "code"}
+
+// websiteAliasIndex allows a single live alias per user and website
+const websiteAliasIndex = "idx_aliases_user_website"
+
+// migrate applies the schema changes AutoMigrate cannot handle
+func migrate(db *gorm.DB) error {
+	if err := dropPlaintextVerificationColumns(db); err != nil {
+		return err
+	}
+
+	return createWebsiteAliasIndex(db)
+}
+
+// dropPlaintextVerificationColumns drops the plaintext verification columns,
+// invalidating the pending links and codes. Owners of unverified emails have
+// to request a new verification email.
+func dropPlaintextVerificationColumns(db *gorm.DB) error {
+	migrator := db.Migrator()
+	for _, column := range plaintextVerificationColumns {
+		if !migrator.HasColumn(&models.Email{}, column) {
+			continue
+		}
+		if err := migrator.DropColumn(&models.Email{}, column); err != nil {
+			return err
+		}
+		log.Info().Str("column", column).Msg("Dropped plaintext verification column, pending verifications must be resent")
+	}
+
+	return nil
+}
+
+// createWebsiteAliasIndex creates the partial unique index of the website
+// aliases. Duplicates created before it existed keep forwarding, but only the
+// oldest one stays the alias of the website.
+func createWebsiteAliasIndex(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&models.Alias{}, websiteAliasIndex) {
+		return nil
+	}
+
+	result := db.Model(&models.Alias{}).
+		Where("website <> '' AND EXISTS (SELECT 1 FROM aliases AS older "+
+			"WHERE older.user_id = aliases.user_id AND older.website = aliases.website AND older.deleted_at IS NULL "+
+			"AND (older.created_at < aliases.created_at OR (older.created_at = aliases.created_at AND older.id < aliases.id)))").
+		Update("website", "")
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Info().Int64("count", result.RowsAffected).Msg("Detached duplicate website aliases from their website")
+	}
+
+	return db.Exec("CREATE UNIQUE INDEX " + websiteAliasIndex +
+		" ON aliases (user_id, website) WHERE website <> '' AND deleted_at IS NULL").Error
+}
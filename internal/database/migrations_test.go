@@ -0,0 +1,63 @@
+package database
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"gorm.io/gorm"
+)
+
+func TestWebsiteAliasIndexDetachesDuplicates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aliasme.db")
+	db, err := New(&Config{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !db.Migrator().HasIndex(&models.Alias{}, websiteAliasIndex) {
+		t.Fatal("website alias index not created")
+	}
+
+	// Duplicates created before the index existed
+	if err := db.Migrator().DropIndex(&models.Alias{}, websiteAliasIndex); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	aliases := []models.Alias{
+		{ID: "oldest", UserID: "alice", AliasAddress: "example.a@example.net", Website: "example.org", CreatedAt: now.Add(-time.Hour)},
+		{ID: "newer", UserID: "alice", AliasAddress: "example.b@example.net", Website: "example.org", CreatedAt: now},
+		{ID: "trashed", UserID: "alice", AliasAddress: "example.c@example.net", Website: "example.org", CreatedAt: now.Add(-2 * time.Hour),
+			DeletedAt: gorm.DeletedAt{Time: now, Valid: true}},
+		{ID: "other", UserID: "bob", AliasAddress: "example.d@example.net", Website: "example.org", CreatedAt: now},
+	}
+	if err := db.Create(&aliases).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if db, err = New(&Config{Path: path}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := map[string]string{"oldest": "example.org", "newer": "", "trashed": "example.org", "other": "example.org"}
+	var stored []models.Alias
+	if err := db.Unscoped().Find(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, alias := range stored {
+		if alias.Website != want[alias.ID] {
+			t.Fatalf("website of %s = %q, want %q", alias.ID, alias.Website, want[alias.ID])
+		}
+	}
+	if !db.Migrator().HasIndex(&models.Alias{}, websiteAliasIndex) {
+		t.Fatal("website alias index not created")
+	}
+
+	duplicate := models.Alias{ID: "duplicate", UserID: "alice", AliasAddress: "example.e@example.net", Website: "example.org"}
+	if err := db.Create(&duplicate).Error; !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("duplicate website alias: error = %v, want %v", err, gorm.ErrDuplicatedKey)
+	}
+}
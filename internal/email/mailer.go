@@ -0,0 +1,61 @@
+package email
+
+import (
+	"fmt"
+)
+
+// Mail transports
+const (
+	TransportSMTP     = "smtp"
+	TransportSendmail = "sendmail"
+	TransportFile     = "file"
+	TransportMaildir  = "maildir"
+)
+
+// SMTP TLS modes
+const (
+	// TLSStartTLS upgrades the connection with STARTTLS, usually on port 587
+	TLSStartTLS = "starttls"
+	// TLSImplicit connects over TLS, usually on port 465
+	TLSImplicit = "tls"
+	// TLSNone sends in clear text, e.g. to a local relay
+	TLSNone = "none"
+)
+
+// Mailer delivers MIME messages
+type Mailer interface {
+	Send(from string, to []string, msg []byte) error
+}
+
+// MailerConfig holds the mail transport configuration
+type MailerConfig struct {
+	// Transport is one of smtp, sendmail, file or maildir
+	Transport string
+	Host      string
+	Port      string
+	// Username and Password enable SMTP authentication when set
+	Username string
+	Password string
+	// TLS is the SMTP TLS mode: starttls, tls or none
+	TLS string
+	// SendmailPath is the sendmail binary used by the sendmail transport
+	SendmailPath string
+	// Dir is where the file and maildir transports write messages
+	Dir string
+}
+
+// NewMailer creates the mailer selected by the configuration
+func NewMailer(cfg MailerConfig) (Mailer, error) {
+	switch cfg.Transport {
+	case "", TransportSMTP:
+		return newSMTPMailer(cfg)
+	case TransportSendmail:
+		return newSendmailMailer(cfg), nil
+	case TransportFile:
+		return newFileMailer(cfg.Dir, false)
+	case TransportMaildir:
+		return newFileMailer(cfg.Dir, true)
+	default:
+		return nil, fmt.Errorf("unknown mail transport %q", cfg.Transport)
+	}
+}
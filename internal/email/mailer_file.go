@@ -0,0 +1,59 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// fileMailer writes messages to a directory instead of delivering them,
+// either as individual .eml files or in a maildir
+type fileMailer struct {
+	dir     string
+	maildir bool
+}
+
+// newFileMailer creates a file or maildir mailer, creating its directories
+func newFileMailer(dir string, maildir bool) (*fileMailer, error) {
+	if dir == "" {
+		return nil, errors.New("mail directory is required")
+	}
+
+	dirs := []string{dir}
+	if maildir {
+		dirs = []string{filepath.Join(dir, "tmp"), filepath.Join(dir, "new"), filepath.Join(dir, "cur")}
+	}
+	for _, d := range dirs {
+		if err := os.MkdirAll(d, 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create mail directory: %w", err)
+		}
+	}
+
+	return &fileMailer{dir: dir, maildir: maildir}, nil
+}
+
+// Send writes the message to the directory
+func (m *fileMailer) Send(from string, to []string, msg []byte) error {
+	if !m.maildir {
+		name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), xid.New().String())
+		return os.WriteFile(filepath.Join(m.dir, name), msg, 0o600)
+	}
+
+	// Maildir delivery: write to tmp, then move to new
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+	name := fmt.Sprintf("%d.%s.%s", time.Now().Unix(), xid.New().String(), hostname)
+
+	tmp := filepath.Join(m.dir, "tmp", name)
+	if err := os.WriteFile(tmp, msg, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, filepath.Join(m.dir, "new", name))
+}
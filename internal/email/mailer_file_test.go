@@ -0,0 +1,152 @@
+package email
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileMailerWritesMessage(t *testing.T) {
+	dir := t.TempDir()
+	mailer, err := NewMailer(MailerConfig{Transport: TransportFile, Dir: dir})
+	if err != nil {
+		t.Fatalf("NewMailer: %v", err)
+	}
+
+	msg := testMessage(t)
+	if err := mailer.Send("noreply@example.com", []string{"alice@example.org"}, msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || !strings.HasSuffix(entries[0].Name(), ".eml") {
+		t.Fatalf("expected one .eml file, got %v", entries)
+	}
+	assertWrittenMessage(t, filepath.Join(dir, entries[0].Name()), msg)
+}
+
+func TestMaildirMailerDeliversToNew(t *testing.T) {
+	dir := t.TempDir()
+	mailer, err := NewMailer(MailerConfig{Transport: TransportMaildir, Dir: dir})
+	if err != nil {
+		t.Fatalf("NewMailer: %v", err)
+	}
+
+	msg := testMessage(t)
+	if err := mailer.Send("noreply@example.com", []string{"alice@example.org"}, msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	for _, sub := range []string{"tmp", "cur"} {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if err != nil {
+			t.Fatalf("maildir %s: %v", sub, err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("expected %s to be empty, got %v", sub, entries)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "new"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected one message in new, got %v", entries)
+	}
+	assertWrittenMessage(t, filepath.Join(dir, "new", entries[0].Name()), msg)
+}
+
+func TestFileMailerRequiresDir(t *testing.T) {
+	for _, transport := range []string{TransportFile, TransportMaildir} {
+		if _, err := NewMailer(MailerConfig{Transport: transport}); err == nil {
+			t.Errorf("%s: expected an error without a directory", transport)
+		}
+	}
+}
+
+// testMessage builds a verification-like message
+func testMessage(t *testing.T) []byte {
+	t.Helper()
+
+	msg, err := buildMessage("noreply@example.com", "alice@example.org", &Content{
+		Subject: "Vérifiez votre adresse",
+		Text:    "Open http://localhost:8080/verify?token=abc",
+		HTML:    `<a href="http://localhost:8080/verify?token=abc">Verify</a>`,
+	}, nil)
+	if err != nil {
+		t.Fatalf("buildMessage: %v", err)
+	}
+
+	return msg
+}
+
+// assertWrittenMessage checks the file holds the message, with its headers
+// and both alternative parts
+func assertWrittenMessage(t *testing.T, path string, want []byte) {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected 0600 permissions, got %o", perm)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, want) {
+		t.Fatal("written message differs from the sent message")
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid message: %v", err)
+	}
+	if got := parsed.Header.Get("To"); got != "alice@example.org" {
+		t.Errorf("To = %q", got)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != "Vérifiez votre adresse" {
+		t.Errorf("Subject = %q (%v)", subject, err)
+	}
+
+	_, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("Content-Type: %v", err)
+	}
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var types []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid part: %v", err)
+		}
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(body), "verify?token=abc") {
+			t.Errorf("part %s misses the link: %q", part.Header.Get("Content-Type"), body)
+		}
+		types = append(types, part.Header.Get("Content-Type"))
+	}
+	if len(types) != 2 || !strings.HasPrefix(types[0], "text/plain") || !strings.HasPrefix(types[1], "text/html") {
+		t.Errorf("expected text and HTML parts, got %v", types)
+	}
+}
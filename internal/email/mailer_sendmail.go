@@ -0,0 +1,42 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// defaultSendmailPath is the usual location of the sendmail binary
+const defaultSendmailPath = "/usr/sbin/sendmail"
+
+// sendmailMailer hands messages to a local sendmail compatible binary
+type sendmailMailer struct {
+	path string
+}
+
+// newSendmailMailer creates a sendmail mailer
+func newSendmailMailer(cfg MailerConfig) *sendmailMailer {
+	path := cfg.SendmailPath
+	if path == "" {
+		path = defaultSendmailPath
+	}
+
+	return &sendmailMailer{path: path}
+}
+
+// Send pipes the message to sendmail
+func (m *sendmailMailer) Send(from string, to []string, msg []byte) error {
+	args := append([]string{"-i", "-f", from, "--"}, to...)
+	cmd := exec.Command(m.path, args...)
+	cmd.Stdin = bytes.NewReader(msg)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sendmail failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/rs/xid"
+)
+
+// buildMessage assembles a multipart/alternative MIME message holding the
+// plain text and HTML versions of the content. When a recipient key is given
+// the message is PGP/MIME encrypted to it (RFC 3156).
+func buildMessage(from, to string, content *Content, recipientKey *openpgp.Entity) ([]byte, error) {
+	contentType, body, err := buildAlternative(content)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := mime.QEncoding.Encode("utf-8", content.Subject)
+	if recipientKey != nil {
+		// The real subject is only in the encrypted part, as protected
+		// headers
+		inner := headerBlock([][2]string{
+			{"Content-Type", contentType + `; protected-headers="v1"`},
+			{"From", from},
+			{"To", to},
+			{"Subject", subject},
+		})
+		inner = append(inner, body...)
+
+		contentType, body, err = buildEncrypted(inner, recipientKey)
+		if err != nil {
+			return nil, err
+		}
+		subject = "..."
+	}
+
+	msg := headerBlock([][2]string{
+		{"From", from},
+		{"To", to},
+		{"Subject", subject},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", messageID(from)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", contentType},
+	})
+
+	return append(msg, body...), nil
+}
+
+// buildAlternative returns the content type and body of a
+// multipart/alternative entity with the text and HTML versions of the content
+func buildAlternative(content *Content) (string, []byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", content.Text},
+		{"text/html; charset=utf-8", content.HTML},
+	}
+	for _, part := range parts {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return "", nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return "", nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return "", nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", nil, err
+	}
+
+	return "multipart/alternative; boundary=" + writer.Boundary(), body.Bytes(), nil
+}
+
+// buildEncrypted returns the content type and body of a multipart/encrypted
+// entity holding the MIME entity encrypted to the key
+func buildEncrypted(entity []byte, key *openpgp.Entity) (string, []byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	w, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"application/pgp-encrypted"},
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := io.WriteString(w, "Version: 1\r\n"); err != nil {
+		return "", nil, err
+	}
+
+	w, err = writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":        {`application/octet-stream; name="encrypted.asc"`},
+		"Content-Disposition": {`inline; filename="encrypted.asc"`},
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if err := encryptArmored(w, entity, key); err != nil {
+		return "", nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", nil, err
+	}
+
+	contentType := fmt.Sprintf(`multipart/encrypted; protocol="application/pgp-encrypted"; boundary=%s`, writer.Boundary())
+	return contentType, body.Bytes(), nil
+}
+
+// headerBlock formats the headers followed by the blank line ending them
+func headerBlock(headers [][2]string) []byte {
+	var block bytes.Buffer
+	for _, header := range headers {
+		fmt.Fprintf(&block, "%s: %s\r\n", header[0], header[1])
+	}
+	block.WriteString("\r\n")
+
+	return block.Bytes()
+}
+
+// messageID generates a unique Message-ID in the sender domain
+func messageID(from string) string {
+	address := from
+	if parsed, err := mail.ParseAddress(from); err == nil {
+		address = parsed.Address
+	}
+
+	_, domain := splitAddress(address)
+	if domain == "" {
+		domain = "aliasme"
+	}
+
+	return fmt.Sprintf("<%s@%s>", xid.New().String(), domain)
+}
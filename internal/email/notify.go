@@ -0,0 +1,239 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// notificationDateFormat is how dates are shown in notifications
+const notificationDateFormat = "2006-01-02"
+
+// SendAliasNotification queues the notification of an alias event within the
+// transaction
+func (s *Service) SendAliasNotification(tx *gorm.DB, to, locale string, notification *models.Notification) error {
+	content, err := s.templates.Render("alias_notification", locale, notificationData(notification))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to render alias notification")
+		return err
+	}
+
+	return s.send(tx, to, content)
+}
+
+// SendAliasDigest queues a digest of alias events within the transaction
+func (s *Service) SendAliasDigest(tx *gorm.DB, to, locale string, notifications []models.Notification) error {
+	events := make([]map[string]string, len(notifications))
+	for i := range notifications {
+		events[i] = notificationData(&notifications[i])
+	}
+
+	content, err := s.templates.Render("alias_digest", locale, map[string]any{
+		"Notifications": events,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to render alias digest")
+		return err
+	}
+
+	return s.send(tx, to, content)
+}
+
+// notificationData returns the template data of a notification
+func notificationData(notification *models.Notification) map[string]string {
+	data := map[string]string{
+		"Event":       notification.Event,
+		"Alias":       notification.AliasAddress,
+		"Destination": notification.Destination,
+		"ExpiresAt":   "",
+	}
+	if notification.ExpiresAt != nil {
+		data["ExpiresAt"] = notification.ExpiresAt.Format(notificationDateFormat)
+	}
+
+	return data
+}
+
+// notify reports an alias event to the verified address the alias forwards
+// to, right away or in the next digest depending on the owner's preference.
+// Failures are only logged as they must not fail the operation being
+// reported.
+func (s *EmailService) notify(event string, alias *models.Alias, destination string, expiresAt *time.Time) {
+	var user models.User
+	if err := s.db.First(&user, "id = ?", alias.UserID).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error().Err(err).Str("event", event).Msg("Failed to get alias owner")
+		}
+		return
+	}
+	if user.Notifications == models.NotificationsOff {
+		return
+	}
+
+	recipient, err := s.notificationRecipient(alias)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error().Err(err).Str("event", event).Msg("Failed to get alias destination")
+		}
+		return
+	}
+
+	id, err := generateID()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate notification ID")
+		return
+	}
+
+	now := time.Now()
+	notification := &models.Notification{
+		ID:           id,
+		UserID:       user.ID,
+		AliasID:      alias.ID,
+		Event:        event,
+		AliasAddress: alias.AliasAddress,
+		Destination:  destination,
+		Recipient:    recipient,
+		ExpiresAt:    expiresAt,
+		CreatedAt:    now,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if user.Notifications != models.NotificationsDigest {
+			notification.SentAt = &now
+			if err := s.emailService.SendAliasNotification(tx, recipient, user.Locale, notification); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(notification).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Str("event", event).Msg("Failed to notify alias owner")
+	}
+}
+
+// notificationRecipient returns the verified address the alias forwards to,
+// the account email being unverified
+func (s *EmailService) notificationRecipient(alias *models.Alias) (string, error) {
+	var email models.Email
+	if err := s.db.First(&email, "id = ? AND user_id = ? AND verified = ?", alias.EmailID, alias.UserID, true).Error; err != nil {
+		return "", err
+	}
+
+	return email.Address, nil
+}
+
+// RunNotifier warns owners of trashed aliases about to be purged and sends
+// the pending digests every interval until the context is cancelled
+func (s *EmailService) RunNotifier(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.notifyExpiring()
+		s.sendDigests()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// notifyExpiring notifies the owners of trashed aliases purged within the
+// expiry notice, once per deletion. Deleted users are not notified.
+func (s *EmailService) notifyExpiring() {
+	retention := s.config.TrashRetention
+	if retention <= 0 || s.config.ExpiryNotice <= 0 {
+		return
+	}
+
+	notice := min(s.config.ExpiryNotice, retention)
+	var aliases []models.Alias
+	err := s.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at <= ?", time.Now().Add(notice-retention)).
+		Where("user_id IN (?)", s.db.Model(&models.User{}).Select("id")).
+		Where("NOT EXISTS (SELECT 1 FROM notifications WHERE notifications.alias_id = aliases.id "+
+			"AND notifications.event = ? AND notifications.created_at >= aliases.deleted_at)", models.EventAliasExpiring).
+		Find(&aliases).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list expiring aliases")
+		return
+	}
+
+	for i := range aliases {
+		expiresAt := aliases[i].DeletedAt.Time.Add(retention)
+		s.notify(models.EventAliasExpiring, &aliases[i], "", &expiresAt)
+	}
+}
+
+// sendDigests sends one digest per user with pending notifications
+func (s *EmailService) sendDigests() {
+	var userIDs []string
+	if err := s.db.Model(&models.Notification{}).Where("sent_at IS NULL").Distinct().Pluck("user_id", &userIDs).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to list pending notifications")
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := s.sendDigest(userID); err != nil {
+			log.Error().Err(err).Str("user_id", userID).Msg("Failed to send notification digest")
+		}
+	}
+}
+
+// sendDigest queues one digest of the pending notifications of a user per
+// address their aliases forward to. They are discarded if the user has turned
+// notifications off since or has been deleted.
+func (s *EmailService) sendDigest(userID string) error {
+	var user models.User
+	if err := s.db.First(&user, "id = ?", userID).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		user.Notifications = models.NotificationsOff
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var notifications []models.Notification
+		if err := tx.Where("user_id = ? AND sent_at IS NULL", userID).Order("created_at").Find(&notifications).Error; err != nil {
+			return err
+		}
+		if len(notifications) == 0 {
+			return nil
+		}
+
+		if user.Notifications != models.NotificationsOff {
+			byRecipient := make(map[string][]models.Notification)
+			var recipients []string
+			for _, notification := range notifications {
+				recipient := notification.Recipient
+				if _, ok := byRecipient[recipient]; !ok {
+					recipients = append(recipients, recipient)
+				}
+				byRecipient[recipient] = append(byRecipient[recipient], notification)
+			}
+
+			for _, recipient := range recipients {
+				if err := s.emailService.SendAliasDigest(tx, recipient, user.Locale, byRecipient[recipient]); err != nil {
+					return err
+				}
+			}
+		}
+
+		ids := make([]string, len(notifications))
+		for i := range notifications {
+			ids[i] = notifications[i].ID
+		}
+		return tx.Model(&models.Notification{}).Where("id IN ?", ids).Update("sent_at", time.Now()).Error
+	})
+}
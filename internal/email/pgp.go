@@ -0,0 +1,236 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// SetPGPKey attaches an OpenPGP public key to a verified email after checking
+// it matches the expected fingerprint
+func (s *EmailService) SetPGPKey(ctx context.Context, req *aliasme.SetPGPKeyRequest) (*aliasme.Email, error) {
+	if req.PublicKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "public_key is required")
+	}
+	expected := normalizeFingerprint(req.Fingerprint)
+	if expected == "" {
+		return nil, status.Error(codes.InvalidArgument, "fingerprint is required")
+	}
+
+	var email models.Email
+	if err := s.db.First(&email, "id = ?", req.EmailId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "email not found")
+		}
+		log.Error().Err(err).Msg("Failed to get email")
+		return nil, status.Error(codes.Internal, "failed to get email")
+	}
+	if err := auth.Authorize(ctx, email.UserID); err != nil {
+		return nil, err
+	}
+	if !email.Verified {
+		return nil, status.Error(codes.FailedPrecondition, "email must be verified before attaching a PGP key")
+	}
+
+	entity, err := parsePublicKey(req.PublicKey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if actual := fingerprint(entity); actual != expected {
+		return nil, status.Errorf(codes.InvalidArgument, "fingerprint %s does not match the key fingerprint %s", expected, actual)
+	}
+
+	// Store the public parts only, re-armored
+	publicKey, err := armorPublicKey(entity)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to armor PGP key")
+		return nil, status.Error(codes.Internal, "failed to store PGP key")
+	}
+
+	email.PGPKey = publicKey
+	email.PGPFingerprint = expected
+	email.UpdatedAt = time.Now()
+
+	if err := s.db.Save(&email).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to update email")
+		return nil, status.Error(codes.Internal, "failed to update email")
+	}
+
+	return emailToProto(&email), nil
+}
+
+// RemovePGPKey removes the OpenPGP public key of an email, mail to it being
+// sent in clear again
+func (s *EmailService) RemovePGPKey(ctx context.Context, req *aliasme.RemovePGPKeyRequest) (*aliasme.Email, error) {
+	var email models.Email
+	if err := s.db.First(&email, "id = ?", req.EmailId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "email not found")
+		}
+		log.Error().Err(err).Msg("Failed to get email")
+		return nil, status.Error(codes.Internal, "failed to get email")
+	}
+	if err := auth.Authorize(ctx, email.UserID); err != nil {
+		return nil, err
+	}
+
+	email.PGPKey = ""
+	email.PGPFingerprint = ""
+	email.UpdatedAt = time.Now()
+
+	if err := s.db.Save(&email).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to update email")
+		return nil, status.Error(codes.Internal, "failed to update email")
+	}
+
+	return emailToProto(&email), nil
+}
+
+// errUnusableKey reports an attached OpenPGP key unable to encrypt anymore,
+// e.g. expired or revoked
+var errUnusableKey = errors.New("PGP key is not usable")
+
+// recipientKey returns the OpenPGP key attached to a verified address, nil
+// when it has none
+func recipientKey(tx *gorm.DB, address string) (*models.Email, *openpgp.Entity, error) {
+	var email models.Email
+	err := tx.Where("address = ? AND verified = ? AND pgp_key <> ''", address, true).
+		Limit(1).
+		Find(&email).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	if email.PGPKey == "" {
+		return nil, nil, nil
+	}
+
+	entity, err := parsePublicKey(email.PGPKey)
+	if err != nil {
+		return &email, nil, fmt.Errorf("%w for %s: %w", errUnusableKey, address, err)
+	}
+
+	return &email, entity, nil
+}
+
+// removeUnusableKey detaches a key which can no longer encrypt from the email
+// and tells its owner, in clear, so that mail such as password resets still
+// reaches them
+func (s *Service) removeUnusableKey(tx *gorm.DB, email *models.Email) error {
+	err := tx.Model(&models.Email{}).
+		Where("id = ?", email.ID).
+		Updates(map[string]any{"pgp_key": "", "pgp_fingerprint": "", "updated_at": time.Now()}).Error
+	if err != nil {
+		return err
+	}
+
+	var user models.User
+	if err := tx.Select("locale").First(&user, "id = ?", email.UserID).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	content, err := s.templates.Render("pgp_key_removed", user.Locale, map[string]any{
+		"Address":     email.Address,
+		"Fingerprint": email.PGPFingerprint,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to render PGP key removed email")
+		return err
+	}
+
+	return s.sendWithKey(tx, email.Address, content, nil)
+}
+
+// parsePublicKey parses a single armored OpenPGP public key able to encrypt
+// now
+func parsePublicKey(armored string) (*openpgp.Entity, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenPGP public key: %w", err)
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("expected a single OpenPGP key, got %d", len(entities))
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey != nil {
+		return nil, errors.New("a private key was provided, only the public key is needed")
+	}
+
+	now := time.Now()
+	if entity.Revoked(now) {
+		return nil, errors.New("the OpenPGP key is revoked")
+	}
+	if _, ok := entity.EncryptionKey(now); !ok {
+		return nil, errors.New("the OpenPGP key has no valid encryption key, it may have expired")
+	}
+
+	return entity, nil
+}
+
+// fingerprint returns the fingerprint of the primary key in upper case
+// hexadecimal
+func fingerprint(entity *openpgp.Entity) string {
+	return strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint))
+}
+
+// normalizeFingerprint removes the separators and prefix commonly found in
+// displayed fingerprints, e.g. "0x" or "AB12 CD34"
+func normalizeFingerprint(fingerprint string) string {
+	fingerprint = strings.NewReplacer(" ", "", ":", "").Replace(strings.TrimSpace(fingerprint))
+	fingerprint = strings.TrimPrefix(strings.TrimPrefix(fingerprint, "0x"), "0X")
+	return strings.ToUpper(fingerprint)
+}
+
+// armorPublicKey serializes the public parts of the key, armored
+func armorPublicKey(entity *openpgp.Entity) (string, error) {
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := entity.Serialize(w); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// encryptArmored writes the data encrypted to the key as an armored OpenPGP
+// message
+func encryptArmored(out io.Writer, data []byte, key *openpgp.Entity) error {
+	armored, err := armor.Encode(out, "PGP MESSAGE", nil)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := openpgp.Encrypt(armored, []*openpgp.Entity{key}, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := plaintext.Write(data); err != nil {
+		return err
+	}
+	if err := plaintext.Close(); err != nil {
+		return err
+	}
+
+	return armored.Close()
+}
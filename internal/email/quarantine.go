@@ -0,0 +1,74 @@
+package email
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// checkAddressAvailable returns an AlreadyExists error if another alias, live
+// or in the trash, has the address, and a FailedPrecondition error if it is
+// quarantined for another user
+func (s *EmailService) checkAddressAvailable(tx *gorm.DB, address, userID, aliasID string) error {
+	var taken int64
+	err := tx.Unscoped().Model(&models.Alias{}).Where("alias_address = ? AND id <> ?", address, aliasID).Count(&taken).Error
+	if err != nil {
+		return status.Error(codes.Internal, "failed to check alias address")
+	}
+	if taken > 0 {
+		return addressTaken(address)
+	}
+
+	return s.checkQuarantine(tx, address, userID)
+}
+
+// addressTaken returns the error of an alias address already in use
+func addressTaken(address string) error {
+	return status.Errorf(codes.AlreadyExists, "alias address %s is already taken", address)
+}
+
+// checkQuarantine returns an error if the address is still quarantined for
+// a user other than userID
+func (s *EmailService) checkQuarantine(tx *gorm.DB, address, userID string) error {
+	var entry models.QuarantinedAddress
+	err := tx.First(&entry, "address = ? AND release_at > ?", address, time.Now()).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return status.Error(codes.Internal, "failed to check address quarantine")
+	}
+
+	if entry.UserID != userID {
+		return status.Errorf(codes.FailedPrecondition, "alias address %s is quarantined until %s",
+			address, entry.ReleaseAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// quarantineAddress prevents the alias address from being reissued to
+// another user during the configured quarantine period
+func (s *EmailService) quarantineAddress(tx *gorm.DB, alias *models.Alias) error {
+	if s.config.QuarantinePeriod <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	entry := &models.QuarantinedAddress{
+		Address:   alias.AliasAddress,
+		UserID:    alias.UserID,
+		ReleaseAt: now.Add(s.config.QuarantinePeriod),
+		CreatedAt: now,
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "release_at", "created_at"}),
+	}).Create(entry).Error
+}
@@ -0,0 +1,163 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// queueBatchSize is the maximum number of messages delivered per poll
+const queueBatchSize = 50
+
+// QueueConfig holds the outgoing mail queue configuration
+type QueueConfig struct {
+	// PollInterval is how often the queue is checked for due messages
+	PollInterval time.Duration
+	// MaxAttempts is the number of delivery attempts before a message is
+	// dead-lettered
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled on each attempt
+	Backoff time.Duration
+	// MaxBackoff caps the delay between two attempts
+	MaxBackoff time.Duration
+	// Retention is how long sent messages, without their body, and dead
+	// messages are listed. Zero keeps them forever.
+	Retention time.Duration
+}
+
+// enqueue stores a message for delivery by the queue worker
+func (s *Service) enqueue(tx *gorm.DB, from, to, subject string, msg []byte) error {
+	id, err := generateID()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return tx.Create(&models.OutgoingMail{
+		ID:            id,
+		Sender:        from,
+		Recipient:     to,
+		Subject:       subject,
+		Message:       msg,
+		Status:        models.MailStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}).Error
+}
+
+// RunQueue delivers the queued messages until the context is cancelled
+func (s *Service) RunQueue(ctx context.Context) {
+	interval := s.config.Queue.PollInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.deliverDue()
+		s.purgeDone()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverDue attempts to deliver the pending messages that are due
+func (s *Service) deliverDue() {
+	var messages []models.OutgoingMail
+	err := s.db.Where("status = ? AND next_attempt_at <= ?", models.MailStatusPending, time.Now()).
+		Order("next_attempt_at").
+		Limit(queueBatchSize).
+		Find(&messages).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load mail queue")
+		return
+	}
+
+	for i := range messages {
+		s.deliver(&messages[i])
+	}
+}
+
+// deliver attempts to deliver a message and records the outcome
+func (s *Service) deliver(message *models.OutgoingMail) {
+	now := time.Now()
+	message.Attempts++
+	message.UpdatedAt = now
+
+	if err := s.mailer.Send(message.Sender, []string{message.Recipient}, message.Message); err != nil {
+		message.LastError = err.Error()
+		if s.config.Queue.MaxAttempts > 0 && message.Attempts >= s.config.Queue.MaxAttempts {
+			message.Status = models.MailStatusDead
+			log.Error().Err(err).Str("id", message.ID).Int("attempts", message.Attempts).Msg("Giving up on mail delivery")
+		} else {
+			message.NextAttemptAt = now.Add(s.retryDelay(message.Attempts))
+			log.Warn().Err(err).Str("id", message.ID).Time("next_attempt_at", message.NextAttemptAt).Msg("Failed to deliver mail")
+		}
+	} else {
+		message.Status = models.MailStatusSent
+		message.LastError = ""
+		message.SentAt = &now
+	}
+	// The body holds verification and reset links, so it is only kept
+	// until the message is delivered. Dead messages keep it to be retried.
+	if message.Status == models.MailStatusSent {
+		message.Message = nil
+	}
+
+	if err := s.db.Save(message).Error; err != nil {
+		log.Error().Err(err).Str("id", message.ID).Msg("Failed to update queued mail")
+	}
+}
+
+// purgeDone drops the body of delivered messages, and deletes delivered and
+// dead messages once past the retention
+func (s *Service) purgeDone() {
+	done := []string{models.MailStatusSent, models.MailStatusDead}
+
+	err := s.db.Model(&models.OutgoingMail{}).
+		Where("status = ? AND message IS NOT NULL", models.MailStatusSent).
+		Update("message", nil).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to clear delivered mail")
+	}
+
+	if s.config.Queue.Retention <= 0 {
+		return
+	}
+	result := s.db.Where("status IN ? AND updated_at < ?", done, time.Now().Add(-s.config.Queue.Retention)).
+		Delete(&models.OutgoingMail{})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Failed to purge mail queue")
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Info().Int64("count", result.RowsAffected).Msg("Purged mail queue")
+	}
+}
+
+// retryDelay returns the exponential backoff after the given attempt
+func (s *Service) retryDelay(attempts int) time.Duration {
+	delay := s.config.Queue.Backoff
+	if delay <= 0 {
+		delay = 30 * time.Second
+	}
+
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if s.config.Queue.MaxBackoff > 0 && delay >= s.config.Queue.MaxBackoff {
+			return s.config.Queue.MaxBackoff
+		}
+	}
+
+	return delay
+}
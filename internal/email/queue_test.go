@@ -0,0 +1,115 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/admin"
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/database"
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+)
+
+// outageMailer fails every delivery while down
+type outageMailer struct {
+	down bool
+	sent [][]byte
+}
+
+func (m *outageMailer) Send(from string, to []string, msg []byte) error {
+	if m.down {
+		return errors.New("connection refused")
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func TestQueueRetriesDeadMail(t *testing.T) {
+	db, err := database.New(&database.Config{Path: filepath.Join(t.TempDir(), "aliasme.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mailer := &outageMailer{down: true}
+	s := New(db, Config{Queue: QueueConfig{
+		MaxAttempts: 3,
+		Backoff:     time.Minute,
+		MaxBackoff:  90 * time.Second,
+		Retention:   time.Hour,
+	}}, mailer)
+
+	body := []byte("Subject: Reset your password\r\n\r\nhttp://localhost/reset-password?token=secret\r\n")
+	if err := s.enqueue(db, "noreply@example.com", "alice@example.org", "Reset your password", body); err != nil {
+		t.Fatal(err)
+	}
+
+	var mail models.OutgoingMail
+	load := func() {
+		t.Helper()
+		if err := db.First(&mail).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Each failure is retried after the backoff, doubled and capped
+	for attempt, backoff := range []time.Duration{time.Minute, 90 * time.Second} {
+		before := time.Now()
+		s.deliverDue()
+		load()
+		if mail.Status != models.MailStatusPending || mail.Attempts != attempt+1 {
+			t.Fatalf("attempt %d: status = %s, attempts = %d", attempt+1, mail.Status, mail.Attempts)
+		}
+		if delay := mail.NextAttemptAt.Sub(before); delay < backoff || delay > backoff+time.Second {
+			t.Fatalf("attempt %d: next attempt in %s, want %s", attempt+1, delay, backoff)
+		}
+
+		// Not due yet
+		s.deliverDue()
+		load()
+		if mail.Attempts != attempt+1 {
+			t.Fatalf("attempt %d: delivered before the backoff", attempt+1)
+		}
+		if err := db.Model(&mail).Update("next_attempt_at", time.Now().Add(-time.Second)).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.deliverDue()
+	s.purgeDone()
+	load()
+	if mail.Status != models.MailStatusDead || mail.LastError == "" {
+		t.Fatalf("status = %s, last error = %q, want dead", mail.Status, mail.LastError)
+	}
+	if string(mail.Message) != string(body) {
+		t.Fatal("dead mail lost its body")
+	}
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserID: "admin", Role: models.RoleAdmin})
+	retried, err := admin.New(db).RetryMail(ctx, &aliasme.RetryMailRequest{Id: mail.ID})
+	if err != nil {
+		t.Fatalf("RetryMail: %v", err)
+	}
+	if retried.Status != models.MailStatusPending {
+		t.Fatalf("retried status = %s, want pending", retried.Status)
+	}
+
+	mailer.down = false
+	s.deliverDue()
+	s.purgeDone()
+	load()
+	if mail.Status != models.MailStatusSent || mail.SentAt == nil {
+		t.Fatalf("status = %s, want sent", mail.Status)
+	}
+	if mail.Message != nil {
+		t.Fatal("sent mail kept its body")
+	}
+	if len(mailer.sent) != 1 || string(mailer.sent[0]) != string(body) {
+		t.Fatalf("delivered %d messages, want the original one", len(mailer.sent))
+	}
+
+	if _, err := admin.New(db).RetryMail(ctx, &aliasme.RetryMailRequest{Id: mail.ID}); err == nil {
+		t.Fatal("sent mail was retried")
+	}
+}
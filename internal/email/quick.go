@@ -0,0 +1,133 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/plan"
+	"github.com/golgoth31/aliasme/internal/utils"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+var (
+	prefixInvalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+	// secondLevelLabels are skipped when deriving a site name, e.g. "co" in "bbc.co.uk"
+	secondLevelLabels = map[string]bool{"co": true, "com": true, "net": true, "org": true, "gov": true, "ac": true, "edu": true}
+)
+
+// QuickCreateAlias returns the user's alias for a website, creating one with
+// a prefix derived from the hostname if none exists yet
+func (s *EmailService) QuickCreateAlias(ctx context.Context, req *aliasme.QuickCreateAliasRequest) (*aliasme.QuickCreateAliasResponse, error) {
+	userID, err := auth.ActingUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	website := normalizeHostname(req.Hostname)
+	if website == "" {
+		return nil, status.Error(codes.InvalidArgument, "hostname is required")
+	}
+
+	existing, err := s.websiteAlias(userID, website)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return &aliasme.QuickCreateAliasResponse{Alias: aliasToProto(existing)}, nil
+	}
+
+	emailID := req.EmailId
+	if emailID == "" {
+		var email models.Email
+		if err := s.db.Order("created_at").First(&email, "user_id = ? AND verified = ?", userID, true).Error; err != nil {
+			log.Error().Err(err).Msg("Failed to find verified email")
+			return nil, status.Error(codes.FailedPrecondition, "user has no verified email")
+		}
+		emailID = email.ID
+	}
+
+	prefix := prefixFromWebsite(website) + "." + strings.ToLower(utils.GenerateRandomString(6))
+
+	alias, err := s.createAlias(userID, emailID, prefix, req.Domain, website, plan.StrategyWebsite)
+	if status.Code(err) == codes.AlreadyExists {
+		// A concurrent call created the alias of the website first
+		existing, lookupErr := s.websiteAlias(userID, website)
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		if existing != nil {
+			return &aliasme.QuickCreateAliasResponse{Alias: aliasToProto(existing)}, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &aliasme.QuickCreateAliasResponse{
+		Alias:   aliasToProto(alias),
+		Created: true,
+	}, nil
+}
+
+// websiteAlias returns the live alias of the user for the website, nil if
+// there is none
+func (s *EmailService) websiteAlias(userID, website string) (*models.Alias, error) {
+	var alias models.Alias
+	err := s.db.Where("user_id = ? AND website = ?", userID, website).First(&alias).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to look up website alias")
+		return nil, status.Error(codes.Internal, "failed to look up website alias")
+	}
+
+	return &alias, nil
+}
+
+// normalizeHostname extracts a lowercase hostname without port or leading
+// "www." from a hostname or URL
+func normalizeHostname(hostname string) string {
+	hostname = strings.ToLower(strings.TrimSpace(hostname))
+	if strings.Contains(hostname, "://") {
+		if u, err := url.Parse(hostname); err == nil {
+			hostname = u.Hostname()
+		}
+	}
+	hostname, _, _ = strings.Cut(hostname, "/")
+	if host, _, found := strings.Cut(hostname, ":"); found {
+		hostname = host
+	}
+	hostname = strings.TrimSuffix(hostname, ".")
+
+	return strings.TrimPrefix(hostname, "www.")
+}
+
+// prefixFromWebsite derives an alias prefix from the site name, e.g.
+// "github" for "gist.github.com"
+func prefixFromWebsite(website string) string {
+	labels := strings.Split(website, ".")
+	name := labels[0]
+	if len(labels) > 1 {
+		name = labels[len(labels)-2]
+	}
+	if len(labels) > 2 && secondLevelLabels[name] {
+		name = labels[len(labels)-3]
+	}
+
+	name = strings.Trim(prefixInvalidChars.ReplaceAllString(name, "-"), "-")
+	if name == "" {
+		return "site"
+	}
+
+	return name
+}
@@ -0,0 +1,101 @@
+package email
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/plan"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestQuickCreateAliasCreatesOneAliasPerWebsite(t *testing.T) {
+	s, db := newTestEmailService(t)
+	// A single connection interleaves the calls without locking errors
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	alice := createAccount(t, db, "alice")
+	ctx := as(&alice.user)
+
+	const calls = 8
+	var (
+		wg        sync.WaitGroup
+		responses [calls]*aliasme.QuickCreateAliasResponse
+		errs      [calls]error
+	)
+	for i := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i], errs[i] = s.QuickCreateAlias(ctx, &aliasme.QuickCreateAliasRequest{Hostname: "https://www.example.org/login"})
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for i := range calls {
+		if errs[i] != nil {
+			t.Fatalf("call %d: %v", i, errs[i])
+		}
+		if responses[i].Alias.Id != responses[0].Alias.Id {
+			t.Fatalf("call %d returned alias %s, want %s", i, responses[i].Alias.Id, responses[0].Alias.Id)
+		}
+		if responses[i].Created {
+			created++
+		}
+	}
+	if created != 1 {
+		t.Fatalf("%d calls created the alias, want 1", created)
+	}
+
+	var count int64
+	if err := db.Model(&models.Alias{}).Where("user_id = ? AND website = ?", alice.user.ID, "example.org").Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("%d aliases for the website, want 1", count)
+	}
+}
+
+func TestWebsiteAliasesAreUniquePerUser(t *testing.T) {
+	s, db := newTestEmailService(t)
+	alice := createAccount(t, db, "alice")
+	bob := createAccount(t, db, "bob")
+
+	trashed := models.Alias{
+		ID:           "alice-website-old",
+		UserID:       alice.user.ID,
+		EmailID:      alice.email.ID,
+		AliasAddress: "example.old@example.net",
+		Website:      "example.org",
+		DeletedAt:    gorm.DeletedAt{Time: time.Now(), Valid: true},
+	}
+	if err := db.Create(&trashed).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// The trashed alias does not count, nor do the aliases of other users
+	for _, user := range []*models.User{&alice.user, &bob.user} {
+		resp, err := s.QuickCreateAlias(as(user), &aliasme.QuickCreateAliasRequest{Hostname: "example.org"})
+		if err != nil || !resp.Created {
+			t.Fatalf("QuickCreateAlias of %s: created = %v, error = %v", user.Username, resp.GetCreated(), err)
+		}
+	}
+
+	// Bypassing the lookup
+	_, err := s.createAlias(alice.user.ID, alice.email.ID, "example.other", "", "example.org", plan.StrategyWebsite)
+	if got := status.Code(err); got != codes.AlreadyExists {
+		t.Fatalf("second alias for the website: code = %s, want AlreadyExists", got)
+	}
+	_, err = s.UndeleteAlias(as(&alice.user), &aliasme.UndeleteAliasRequest{Id: trashed.ID})
+	if got := status.Code(err); got != codes.AlreadyExists {
+		t.Fatalf("UndeleteAlias: code = %s, want AlreadyExists", got)
+	}
+}
@@ -2,11 +2,14 @@ package email
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/smtp"
-	"os"
+	"net/mail"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/golgoth31/aliasme/internal/models"
 	aliasme "github.com/golgoth31/aliasme/pkg/proto"
 	"github.com/rs/xid"
@@ -17,23 +20,48 @@ import (
 
 // Config holds the email service configuration
 type Config struct {
-	SMTPHost     string
-	SMTPPort     string
-	SMTPUsername string
-	SMTPPassword string
-	FromEmail    string
+	FromEmail string
+	// BaseURL is the public URL of the HTTP server, used in links
+	BaseURL string
+	// Secret keys the hashes of verification tokens and codes stored in
+	// the database
+	Secret []byte
+	// TokenTTL is how long verification links stay valid
+	TokenTTL time.Duration
+	// CodeTTL is how long verification codes stay valid
+	CodeTTL time.Duration
+	// MaxCodeAttempts is the number of wrong codes accepted for an email,
+	// across resends, before only links can verify it
+	MaxCodeAttempts int
+	// ResendInterval is the minimum delay between two verification emails
+	// sent to the same address
+	ResendInterval time.Duration
+	// TemplatesDir is an optional directory overriding the embedded email
+	// templates
+	TemplatesDir string
+	// DefaultLocale is the locale of users without one or whose locale has
+	// no templates
+	DefaultLocale string
+	Queue         QueueConfig
 }
 
 // Service handles email-related operations
 type Service struct {
 	aliasme.UnimplementedEmailServiceServer
-	db     *gorm.DB
-	config Config
+	db        *gorm.DB
+	config    Config
+	templates *Templates
+	mailer    Mailer
 }
 
 // New creates a new email service
-func New(db *gorm.DB, cfg Config) *Service {
-	return &Service{db: db, config: cfg}
+func New(db *gorm.DB, cfg Config, mailer Mailer) *Service {
+	return &Service{
+		db:        db,
+		config:    cfg,
+		templates: NewTemplates(cfg.TemplatesDir, cfg.DefaultLocale),
+		mailer:    mailer,
+	}
 }
 
 // CreateAlias creates a new email alias
@@ -155,43 +183,171 @@ func generateID() (string, error) {
 	return xid.New().String(), nil
 }
 
-// SendVerificationEmail sends a verification email to the user
-func (s *Service) SendVerificationEmail(to, token string) error {
-	subject := "Verify your email address"
-	body := fmt.Sprintf(`
-		Hello,
+// SendVerificationEmail queues a verification email to the user in their
+// locale within the transaction
+func (s *Service) SendVerificationEmail(tx *gorm.DB, to, token, locale string) error {
+	content, err := s.templates.Render("verification", locale, map[string]any{
+		"Address":   to,
+		"Link":      fmt.Sprintf("%s/verify?token=%s", s.config.BaseURL, url.QueryEscape(token)),
+		"ExpiresIn": formatTTL(s.config.TokenTTL, locale),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to render verification email")
+		return err
+	}
 
-		Please verify your email address by clicking the following link:
-		%s/verify?token=%s
+	if err := s.send(tx, to, content); err != nil {
+		log.Error().Err(err).Msg("Failed to queue verification email")
+		return err
+	}
 
-		This link will expire in 24 hours.
+	return nil
+}
 
-		Best regards,
-		The AliasMe Team
-	`, os.Getenv("BASE_URL"), token)
+// SendVerificationCode queues an email with a verification code to the user
+// in their locale within the transaction
+func (s *Service) SendVerificationCode(tx *gorm.DB, to, code, locale string) error {
+	content, err := s.templates.Render("verification_code", locale, map[string]any{
+		"Address":   to,
+		"Code":      code,
+		"ExpiresIn": formatTTL(s.config.CodeTTL, locale),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to render verification code email")
+		return err
+	}
 
-	msg := fmt.Sprintf("From: %s\r\n"+
-		"To: %s\r\n"+
-		"Subject: %s\r\n"+
-		"\r\n"+
-		"%s\r\n",
-		s.config.FromEmail, to, subject, body)
+	if err := s.send(tx, to, content); err != nil {
+		log.Error().Err(err).Msg("Failed to queue verification code email")
+		return err
+	}
+
+	return nil
+}
+
+// SendPasswordReset queues a password reset email to the user in their
+// locale within the transaction
+func (s *Service) SendPasswordReset(tx *gorm.DB, to, token, locale string, ttl time.Duration) error {
+	content, err := s.templates.Render("password_reset", locale, map[string]any{
+		"Address":   to,
+		"Link":      fmt.Sprintf("%s/reset-password?token=%s", s.config.BaseURL, url.QueryEscape(token)),
+		"ExpiresIn": formatTTL(ttl, locale),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to render password reset email")
+		return err
+	}
+
+	if err := s.send(tx, to, content); err != nil {
+		log.Error().Err(err).Msg("Failed to queue password reset email")
+		return err
+	}
+
+	return nil
+}
+
+// SendAccountDeletion queues the email telling the user their account will
+// be erased at deleteAt unless restored with the token, within the
+// transaction
+func (s *Service) SendAccountDeletion(tx *gorm.DB, to, token, locale string, deleteAt time.Time) error {
+	content, err := s.templates.Render("account_deletion", locale, map[string]any{
+		"Address":  to,
+		"Link":     fmt.Sprintf("%s/restore-account?token=%s", s.config.BaseURL, url.QueryEscape(token)),
+		"DeleteAt": deleteAt.Format(notificationDateFormat),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to render account deletion email")
+		return err
+	}
 
-	auth := smtp.PlainAuth("", s.config.SMTPUsername, s.config.SMTPPassword,
-		s.config.SMTPHost)
+	if err := s.send(tx, to, content); err != nil {
+		log.Error().Err(err).Msg("Failed to queue account deletion email")
+		return err
+	}
 
-	err := smtp.SendMail(
-		s.config.SMTPHost+":"+s.config.SMTPPort,
-		auth,
-		s.config.FromEmail,
-		[]string{to},
-		[]byte(msg),
-	)
+	return nil
+}
 
+// SendAccountDeleted queues the email confirming the account was erased,
+// within the transaction
+func (s *Service) SendAccountDeleted(tx *gorm.DB, to, locale string) error {
+	content, err := s.templates.Render("account_deleted", locale, map[string]any{
+		"Address": to,
+	})
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to send verification email")
+		log.Error().Err(err).Msg("Failed to render account deleted email")
+		return err
+	}
+
+	if err := s.send(tx, to, content); err != nil {
+		log.Error().Err(err).Msg("Failed to queue account deleted email")
 		return err
 	}
 
 	return nil
 }
+
+// send builds the MIME message of the content and queues it for delivery
+func (s *Service) send(tx *gorm.DB, to string, content *Content) error {
+	// Mail to addresses with an OpenPGP key is always encrypted
+	email, key, err := recipientKey(tx, to)
+	if errors.Is(err, errUnusableKey) {
+		// The key is treated as absent rather than blocking every mail
+		log.Warn().Err(err).Str("email_id", email.ID).Msg("Removing unusable PGP key")
+		if err := s.removeUnusableKey(tx, email); err != nil {
+			log.Error().Err(err).Msg("Failed to remove unusable PGP key")
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	return s.sendWithKey(tx, to, content, key)
+}
+
+// sendWithKey builds the MIME message of the content, encrypted when a key is
+// given, and queues it for delivery
+func (s *Service) sendWithKey(tx *gorm.DB, to string, content *Content, key *openpgp.Entity) error {
+	msg, err := buildMessage(s.config.FromEmail, to, content, key)
+	if err != nil {
+		return err
+	}
+
+	// The envelope sender is the bare address of the From header
+	from := s.config.FromEmail
+	if parsed, err := mail.ParseAddress(from); err == nil {
+		from = parsed.Address
+	}
+
+	return s.enqueue(tx, from, to, content.Subject, msg)
+}
+
+// durationUnits holds the singular and plural hour and minute words per
+// language
+var durationUnits = map[string][4]string{
+	"en": {"hour", "hours", "minute", "minutes"},
+	"fr": {"heure", "heures", "minute", "minutes"},
+}
+
+// formatTTL formats a token TTL for humans in the locale language, e.g.
+// "24 hours"
+func formatTTL(ttl time.Duration, locale string) string {
+	language, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(locale, "_", "-")), "-")
+	units, ok := durationUnits[language]
+	if !ok {
+		units = durationUnits[defaultLocale]
+	}
+
+	switch {
+	case ttl == time.Hour:
+		return "1 " + units[0]
+	case ttl > time.Hour && ttl%time.Hour == 0:
+		return fmt.Sprintf("%d %s", ttl/time.Hour, units[1])
+	case ttl == time.Minute:
+		return "1 " + units[2]
+	case ttl > time.Minute && ttl%time.Minute == 0:
+		return fmt.Sprintf("%d %s", ttl/time.Minute, units[3])
+	default:
+		return ttl.String()
+	}
+}
@@ -2,40 +2,70 @@ package email
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/golgoth31/aliasme/internal/auth"
 	"github.com/golgoth31/aliasme/internal/models"
 	"github.com/golgoth31/aliasme/internal/ovh"
+	"github.com/golgoth31/aliasme/internal/plan"
+	"github.com/golgoth31/aliasme/internal/utils"
 	aliasme "github.com/golgoth31/aliasme/pkg/proto"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 )
 
+// AliasConfig holds the alias management configuration
+type AliasConfig struct {
+	// QuarantinePeriod is how long a deleted alias address cannot be
+	// reissued to another user. Zero disables the quarantine.
+	QuarantinePeriod time.Duration
+	// Domains lists the domains aliases can be created on, the first one
+	// being the default
+	Domains []string
+	// TrashRetention is how long deleted aliases can be restored. Zero
+	// keeps them forever.
+	TrashRetention time.Duration
+	// ExpiryNotice is how long before being purged owners are warned about
+	// their deleted aliases. Zero disables the warning.
+	ExpiryNotice time.Duration
+}
+
 // EmailService handles email-related operations
 type EmailService struct {
 	aliasme.UnimplementedEmailServiceServer
 	db           *gorm.DB
 	ovhClient    *ovh.Client
 	emailService *Service
+	config       AliasConfig
+	plans        *plan.Catalog
 }
 
 // NewEmailService creates a new email service
-func NewEmailService(db *gorm.DB, ovhClient *ovh.Client, emailService *Service) *EmailService {
+func NewEmailService(db *gorm.DB, ovhClient *ovh.Client, emailService *Service, cfg AliasConfig, plans *plan.Catalog) *EmailService {
 	return &EmailService{
 		db:           db,
 		ovhClient:    ovhClient,
 		emailService: emailService,
+		config:       cfg,
+		plans:        plans,
 	}
 }
 
 // RegisterEmail registers a new email address for a user
 func (s *EmailService) RegisterEmail(ctx context.Context, req *aliasme.RegisterEmailRequest) (*aliasme.Email, error) {
-	// Generate verification token
-	token, err := generateToken()
+	userID, err := auth.ActingUser(ctx, req.UserId)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to generate verification token")
+		return nil, err
+	}
+
+	if err := s.checkEmailQuota(userID); err != nil {
 		return nil, err
 	}
 
@@ -46,47 +76,65 @@ func (s *EmailService) RegisterEmail(ctx context.Context, req *aliasme.RegisterE
 		return nil, err
 	}
 
+	now := time.Now()
 	email := &models.Email{
 		ID:        id,
-		UserID:    req.UserId,
+		UserID:    userID,
 		Address:   req.EmailAddress,
 		Verified:  false,
-		Token:     token,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 
-	if err := s.db.Create(email).Error; err != nil {
-		log.Error().Err(err).Msg("Failed to create email")
+	// Generate verification token or code
+	token, err := s.newVerification(email, req.VerificationMethod)
+	if err != nil {
 		return nil, err
 	}
 
-	// Send verification email
-	if err := s.emailService.SendVerificationEmail(email.Address, token); err != nil {
-		log.Error().Err(err).Msg("Failed to send verification email")
+	// The verification email is queued with the email so neither exists
+	// without the other
+	locale := s.userLocale(email.UserID)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(email).Error; err != nil {
+			log.Error().Err(err).Msg("Failed to create email")
+			return err
+		}
+
+		return s.sendVerification(tx, email, token, locale)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &aliasme.Email{
-		Id:        email.ID,
-		UserId:    email.UserID,
-		Address:   email.Address,
-		Verified:  email.Verified,
-		CreatedAt: timestamppb.New(email.CreatedAt),
-		UpdatedAt: timestamppb.New(email.UpdatedAt),
-	}, nil
+	return emailToProto(email), nil
 }
 
 // VerifyEmail verifies an email address using the token
 func (s *EmailService) VerifyEmail(ctx context.Context, req *aliasme.VerifyEmailRequest) (*aliasme.Email, error) {
+	if req.Token == "" {
+		if req.EmailId != "" || req.Code != "" {
+			return s.verifyCode(req.EmailId, req.Code)
+		}
+		return nil, status.Error(codes.InvalidArgument, "token, or email_id and code, are required")
+	}
+
+	// Only the hash of the token is stored
 	var email models.Email
-	if err := s.db.First(&email, "token = ?", req.Token).Error; err != nil {
+	hash := utils.HashToken(s.emailService.config.Secret, req.Token)
+	if err := s.db.First(&email, "token_hash = ?", hash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "verification token not found")
+		}
 		log.Error().Err(err).Msg("Failed to find email with token")
-		return nil, err
+		return nil, status.Error(codes.Internal, "failed to find email with token")
+	}
+	if s.verificationExpired(&email, s.emailService.config.TokenTTL) {
+		return nil, status.Error(codes.DeadlineExceeded, "verification token has expired")
 	}
 
 	email.Verified = true
-	email.Token = ""
+	email.TokenHash = ""
 	email.UpdatedAt = time.Now()
 
 	if err := s.db.Save(&email).Error; err != nil {
@@ -94,21 +142,40 @@ func (s *EmailService) VerifyEmail(ctx context.Context, req *aliasme.VerifyEmail
 		return nil, err
 	}
 
-	return &aliasme.Email{
-		Id:        email.ID,
-		UserId:    email.UserID,
-		Address:   email.Address,
-		Verified:  email.Verified,
-		CreatedAt: timestamppb.New(email.CreatedAt),
-		UpdatedAt: timestamppb.New(email.UpdatedAt),
-	}, nil
+	return emailToProto(&email), nil
 }
 
 // CreateAlias creates a new email alias
 func (s *EmailService) CreateAlias(ctx context.Context, req *aliasme.CreateAliasRequest) (*aliasme.Alias, error) {
+	userID, err := auth.ActingUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	alias, err := s.createAlias(userID, req.EmailId, req.AliasPrefix, req.Domain, "", plan.StrategyCustom)
+	if err != nil {
+		return nil, err
+	}
+
+	return aliasToProto(alias), nil
+}
+
+// createAlias provisions an alias in OVH and stores it for the user
+func (s *EmailService) createAlias(userID, emailID, prefix, domain, website, strategy string) (*models.Alias, error) {
+	if domain == "" && len(s.config.Domains) > 0 {
+		domain = s.config.Domains[0]
+	}
+	if !slices.Contains(s.config.Domains, domain) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown alias domain %q", domain)
+	}
+
+	if err := s.checkAliasQuota(userID, domain, strategy); err != nil {
+		return nil, err
+	}
+
 	// Verify that the email belongs to the user and is verified
 	var email models.Email
-	if err := s.db.First(&email, "id = ? AND user_id = ? AND verified = ?", req.EmailId, req.UserId, true).Error; err != nil {
+	if err := s.db.First(&email, "id = ? AND user_id = ? AND verified = ?", emailID, userID, true).Error; err != nil {
 		log.Error().Err(err).Msg("Failed to find verified email")
 		return nil, err
 	}
@@ -120,57 +187,71 @@ func (s *EmailService) CreateAlias(ctx context.Context, req *aliasme.CreateAlias
 		return nil, err
 	}
 
-	// Create alias address
-	aliasAddress := req.AliasPrefix + "@yourdomain.com"
-
-	// Create alias in OVH
-	if err := s.ovhClient.CreateEmailAlias("yourdomain.com", req.AliasPrefix, email.Address); err != nil {
-		log.Error().Err(err).Msg("Failed to create alias in OVH")
-		return nil, err
-	}
-
 	alias := &models.Alias{
 		ID:           id,
-		UserID:       req.UserId,
-		EmailID:      req.EmailId,
-		AliasAddress: aliasAddress,
+		UserID:       userID,
+		EmailID:      emailID,
+		AliasAddress: prefix + "@" + domain,
+		Website:      website,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
 
-	if err := s.db.Create(alias).Error; err != nil {
+	// The address is checked in the transaction storing the alias, so a
+	// concurrent release cannot hand it to two users
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.checkAddressAvailable(tx, alias.AliasAddress, userID, alias.ID); err != nil {
+			return err
+		}
+		if website != "" {
+			var existing int64
+			if err := tx.Model(&models.Alias{}).Where("user_id = ? AND website = ?", userID, website).Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing > 0 {
+				return status.Errorf(codes.AlreadyExists, "an alias for %s already exists", website)
+			}
+		}
+		return tx.Create(alias).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, addressTaken(alias.AliasAddress)
+		}
 		log.Error().Err(err).Msg("Failed to create alias")
 		return nil, err
 	}
 
-	return &aliasme.Alias{
-		Id:           alias.ID,
-		UserId:       alias.UserID,
-		EmailId:      alias.EmailID,
-		AliasAddress: alias.AliasAddress,
-		CreatedAt:    timestamppb.New(alias.CreatedAt),
-		UpdatedAt:    timestamppb.New(alias.UpdatedAt),
-	}, nil
+	// Create alias in OVH once stored, removing it again if OVH fails
+	if err := s.ovhClient.CreateEmailAlias(domain, prefix, email.Address); err != nil {
+		log.Error().Err(err).Msg("Failed to create alias in OVH")
+		if err := s.db.Unscoped().Delete(alias).Error; err != nil {
+			log.Error().Err(err).Str("alias_id", alias.ID).Msg("Failed to remove alias without redirection")
+		}
+		return nil, err
+	}
+
+	s.notify(models.EventAliasCreated, alias, email.Address, nil)
+
+	return alias, nil
 }
 
 // ListAliases lists all aliases for a user
 func (s *EmailService) ListAliases(ctx context.Context, req *aliasme.ListAliasesRequest) (*aliasme.ListAliasesResponse, error) {
+	userID, err := auth.ActingUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
 	var aliases []models.Alias
-	if err := s.db.Find(&aliases, "user_id = ?", req.UserId).Error; err != nil {
+	if err := s.db.Find(&aliases, "user_id = ?", userID).Error; err != nil {
 		log.Error().Err(err).Msg("Failed to list aliases")
 		return nil, err
 	}
 
 	protoAliases := make([]*aliasme.Alias, len(aliases))
 	for i, alias := range aliases {
-		protoAliases[i] = &aliasme.Alias{
-			Id:           alias.ID,
-			UserId:       alias.UserID,
-			EmailId:      alias.EmailID,
-			AliasAddress: alias.AliasAddress,
-			CreatedAt:    timestamppb.New(alias.CreatedAt),
-			UpdatedAt:    timestamppb.New(alias.UpdatedAt),
-		}
+		protoAliases[i] = aliasToProto(&alias)
 	}
 
 	return &aliasme.ListAliasesResponse{
@@ -180,42 +261,222 @@ func (s *EmailService) ListAliases(ctx context.Context, req *aliasme.ListAliases
 
 // DeleteAlias deletes an alias
 func (s *EmailService) DeleteAlias(ctx context.Context, req *aliasme.DeleteAliasRequest) (*aliasme.DeleteAliasResponse, error) {
-	if err := s.db.Delete(&models.Alias{}, "id = ?", req.Id).Error; err != nil {
+	var alias models.Alias
+	if err := s.db.First(&alias, "id = ?", req.Id).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to get alias")
+		return nil, err
+	}
+	if err := auth.Authorize(ctx, alias.UserID); err != nil {
+		return nil, err
+	}
+
+	// Deleting sets DeletedAt on the alias
+	previous := alias
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&alias).Error; err != nil {
+			return err
+		}
+		return s.quarantineAddress(tx, &alias)
+	})
+	if err != nil {
 		log.Error().Err(err).Msg("Failed to delete alias")
 		return nil, err
 	}
 
+	// OVH is updated once the deletion is committed, restoring the alias if
+	// it fails
+	prefix, domain := splitAddress(alias.AliasAddress)
+	if err := s.ovhClient.DeleteEmailAlias(domain, prefix); err != nil {
+		log.Error().Err(err).Msg("Failed to delete alias in OVH")
+		s.revertAlias(&previous)
+		return nil, err
+	}
+
+	var restorableUntil *time.Time
+	if s.config.TrashRetention > 0 {
+		until := time.Now().Add(s.config.TrashRetention)
+		restorableUntil = &until
+	}
+	s.notify(models.EventAliasDisabled, &alias, "", restorableUntil)
+
 	return &aliasme.DeleteAliasResponse{Success: true}, nil
 }
 
-// UpdateAlias updates an alias
+// UpdateAlias updates the alias fields selected by the update mask and
+// propagates the change to the OVH redirection
 func (s *EmailService) UpdateAlias(ctx context.Context, req *aliasme.UpdateAliasRequest) (*aliasme.Alias, error) {
+	paths, err := utils.UpdatePaths(req.UpdateMask, map[string]bool{
+		"email_id":     req.EmailId != "",
+		"alias_prefix": req.AliasPrefix != "",
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if paths["email_id"] && req.EmailId == "" {
+		return nil, status.Error(codes.InvalidArgument, "email_id cannot be empty")
+	}
+	if paths["alias_prefix"] && req.AliasPrefix == "" {
+		return nil, status.Error(codes.InvalidArgument, "alias_prefix cannot be empty")
+	}
+
 	var alias models.Alias
 	if err := s.db.First(&alias, "id = ?", req.Id).Error; err != nil {
 		log.Error().Err(err).Msg("Failed to get alias")
 		return nil, err
 	}
+	if err := auth.Authorize(ctx, alias.UserID); err != nil {
+		return nil, err
+	}
 
-	alias.EmailID = req.EmailId
-	alias.AliasAddress = req.AliasPrefix
+	previous := alias
+	prefix, domain := splitAddress(alias.AliasAddress)
+	if paths["alias_prefix"] {
+		prefix = req.AliasPrefix
+		alias.AliasAddress = prefix + "@" + domain
+	}
+	if paths["email_id"] {
+		alias.EmailID = req.EmailId
+	}
 	alias.UpdatedAt = time.Now()
 
-	if err := s.db.Save(&alias).Error; err != nil {
+	moved := alias.AliasAddress != previous.AliasAddress
+
+	// The alias must still be allowed by the plan, as when it was created.
+	// A new prefix is a custom one.
+	strategy := plan.StrategyCustom
+	if !moved && alias.Website != "" {
+		strategy = plan.StrategyWebsite
+	}
+	if _, err := s.checkAliasPlan(alias.UserID, domain, strategy); err != nil {
+		return nil, err
+	}
+
+	// Verify that the destination belongs to the user and is verified
+	var email models.Email
+	if err := s.db.First(&email, "id = ? AND user_id = ? AND verified = ?", alias.EmailID, alias.UserID, true).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to find verified email")
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if !moved {
+			return tx.Save(&alias).Error
+		}
+
+		// The new address must be free and the old one is released
+		if err := s.checkAddressAvailable(tx, alias.AliasAddress, alias.UserID, alias.ID); err != nil {
+			return err
+		}
+		if err := tx.Save(&alias).Error; err != nil {
+			return err
+		}
+		return s.quarantineAddress(tx, &previous)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, addressTaken(alias.AliasAddress)
+		}
 		log.Error().Err(err).Msg("Failed to update alias")
 		return nil, err
 	}
 
-	return &aliasme.Alias{
+	// OVH is updated once the change is committed, reverting the alias if
+	// it fails
+	if !moved && alias.EmailID == previous.EmailID {
+		return aliasToProto(&alias), nil
+	}
+	if err := s.updateRedirection(&previous, prefix, domain, email.Address, moved); err != nil {
+		log.Error().Err(err).Msg("Failed to update alias in OVH")
+		s.revertAlias(&previous)
+		return nil, err
+	}
+
+	if alias.EmailID != previous.EmailID {
+		s.notify(models.EventAliasTransferred, &alias, email.Address, nil)
+	}
+
+	return aliasToProto(&alias), nil
+}
+
+// updateRedirection moves the OVH redirection of the previous alias to
+// prefix@domain pointing to target, leaving OVH unchanged on failure
+func (s *EmailService) updateRedirection(previous *models.Alias, prefix, domain, target string, moved bool) error {
+	if !moved {
+		return s.ovhClient.UpdateEmailAlias(domain, prefix, target)
+	}
+
+	// Create the new redirection before removing the old one
+	if err := s.ovhClient.CreateEmailAlias(domain, prefix, target); err != nil {
+		return err
+	}
+	previousPrefix, _ := splitAddress(previous.AliasAddress)
+	if err := s.ovhClient.DeleteEmailAlias(domain, previousPrefix); err != nil {
+		if err := s.ovhClient.DeleteEmailAlias(domain, prefix); err != nil {
+			log.Error().Err(err).Str("alias", prefix+"@"+domain).Msg("Failed to remove new redirection")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// revertAlias stores the alias back as it was before a change OVH failed to
+// apply, releasing the quarantine of its address
+func (s *EmailService) revertAlias(previous *models.Alias) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Save(previous).Error; err != nil {
+			return err
+		}
+		return tx.Where("address = ? AND user_id = ?", previous.AliasAddress, previous.UserID).
+			Delete(&models.QuarantinedAddress{}).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Str("alias_id", previous.ID).Msg("Failed to revert alias")
+	}
+}
+
+// emailToProto converts an email model to its protobuf representation
+func emailToProto(email *models.Email) *aliasme.Email {
+	return &aliasme.Email{
+		Id:             email.ID,
+		UserId:         email.UserID,
+		Address:        email.Address,
+		Verified:       email.Verified,
+		PgpFingerprint: email.PGPFingerprint,
+		CreatedAt:      timestamppb.New(email.CreatedAt),
+		UpdatedAt:      timestamppb.New(email.UpdatedAt),
+	}
+}
+
+// generateToken generates a random verification token
+func generateToken() (string, error) {
+	return uuid.New().String(), nil
+}
+
+// splitAddress splits an alias address into its prefix and domain
+func splitAddress(address string) (string, string) {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return address, ""
+	}
+
+	return address[:at], address[at+1:]
+}
+
+// aliasToProto converts an alias model to its protobuf representation
+func aliasToProto(alias *models.Alias) *aliasme.Alias {
+	protoAlias := &aliasme.Alias{
 		Id:           alias.ID,
 		UserId:       alias.UserID,
 		EmailId:      alias.EmailID,
 		AliasAddress: alias.AliasAddress,
+		Website:      alias.Website,
 		CreatedAt:    timestamppb.New(alias.CreatedAt),
 		UpdatedAt:    timestamppb.New(alias.UpdatedAt),
-	}, nil
-}
+	}
+	if alias.DeletedAt.Valid {
+		protoAlias.DeletedAt = timestamppb.New(alias.DeletedAt.Time)
+	}
 
-// generateToken generates a random verification token
-func generateToken() (string, error) {
-	return uuid.New().String(), nil
+	return protoAlias
 }
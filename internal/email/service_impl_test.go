@@ -0,0 +1,305 @@
+package email
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/database"
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/plan"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// newTestEmailService returns an alias service on a fresh database, without
+// OVH client and with a queue that is not run
+func newTestEmailService(t *testing.T) (*EmailService, *gorm.DB) {
+	t.Helper()
+
+	db, err := database.New(&database.Config{Path: filepath.Join(t.TempDir(), "aliasme.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mailer := New(db, Config{FromEmail: "noreply@example.com", BaseURL: "http://localhost:8080", DefaultLocale: "en"}, nil)
+
+	return NewEmailService(db, nil, mailer, AliasConfig{Domains: []string{"example.net"}}, plan.NewCatalog(plan.Config{})), db
+}
+
+// account is a user with a verified email, a live alias and a deleted alias
+type account struct {
+	user    models.User
+	email   models.Email
+	alias   models.Alias
+	deleted models.Alias
+}
+
+// createAccount inserts an account of the user
+func createAccount(t *testing.T, db *gorm.DB, username string) *account {
+	t.Helper()
+
+	a := &account{
+		user:  models.User{ID: username + "-id", Username: username, Email: username + "@example.org", Role: models.RoleUser},
+		email: models.Email{ID: username + "-email", UserID: username + "-id", Address: username + "@example.org", Verified: true},
+	}
+	a.alias = models.Alias{ID: username + "-alias", UserID: a.user.ID, EmailID: a.email.ID, AliasAddress: username + ".shop@example.net"}
+	a.deleted = models.Alias{
+		ID:           username + "-deleted",
+		UserID:       a.user.ID,
+		EmailID:      a.email.ID,
+		AliasAddress: username + ".old@example.net",
+		DeletedAt:    gorm.DeletedAt{Time: time.Now(), Valid: true},
+	}
+	for _, row := range []any{&a.user, &a.email, &a.alias, &a.deleted} {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return a
+}
+
+// as returns the context of a login session of the user
+func as(user *models.User) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{UserID: user.ID, SessionID: "session", Role: user.Role})
+}
+
+func TestUsersCannotAccessOtherAliasesAndEmails(t *testing.T) {
+	s, db := newTestEmailService(t)
+	alice := createAccount(t, db, "alice")
+	bob := createAccount(t, db, "bob")
+	ctx := as(&alice.user)
+
+	calls := map[string]func() error{
+		"ListAliases": func() error {
+			_, err := s.ListAliases(ctx, &aliasme.ListAliasesRequest{UserId: bob.user.ID})
+			return err
+		},
+		"ListDeletedAliases": func() error {
+			_, err := s.ListDeletedAliases(ctx, &aliasme.ListDeletedAliasesRequest{UserId: bob.user.ID})
+			return err
+		},
+		"GetUsage": func() error {
+			_, err := s.GetUsage(ctx, &aliasme.GetUsageRequest{UserId: bob.user.ID})
+			return err
+		},
+		"CreateAlias": func() error {
+			_, err := s.CreateAlias(ctx, &aliasme.CreateAliasRequest{UserId: bob.user.ID, EmailId: bob.email.ID, AliasPrefix: "mallory", Domain: "example.net"})
+			return err
+		},
+		"QuickCreateAlias": func() error {
+			_, err := s.QuickCreateAlias(ctx, &aliasme.QuickCreateAliasRequest{UserId: bob.user.ID, EmailId: bob.email.ID, Hostname: "example.com"})
+			return err
+		},
+		"UpdateAlias": func() error {
+			_, err := s.UpdateAlias(ctx, &aliasme.UpdateAliasRequest{Id: bob.alias.ID, EmailId: alice.email.ID})
+			return err
+		},
+		"DeleteAlias": func() error {
+			_, err := s.DeleteAlias(ctx, &aliasme.DeleteAliasRequest{Id: bob.alias.ID})
+			return err
+		},
+		"UndeleteAlias": func() error {
+			_, err := s.UndeleteAlias(ctx, &aliasme.UndeleteAliasRequest{Id: bob.deleted.ID})
+			return err
+		},
+		"RegisterEmail": func() error {
+			_, err := s.RegisterEmail(ctx, &aliasme.RegisterEmailRequest{UserId: bob.user.ID, EmailAddress: "mallory@example.org"})
+			return err
+		},
+		"ResendVerification": func() error {
+			_, err := s.ResendVerification(ctx, &aliasme.ResendVerificationRequest{EmailId: bob.email.ID})
+			return err
+		},
+		"SetPGPKey": func() error {
+			_, err := s.SetPGPKey(ctx, &aliasme.SetPGPKeyRequest{EmailId: bob.email.ID, PublicKey: "key", Fingerprint: "0123456789ABCDEF"})
+			return err
+		},
+		"RemovePGPKey": func() error {
+			_, err := s.RemovePGPKey(ctx, &aliasme.RemovePGPKeyRequest{EmailId: bob.email.ID})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if got := status.Code(call()); got != codes.PermissionDenied {
+				t.Fatalf("code = %s, want PermissionDenied", got)
+			}
+		})
+	}
+
+	// Nothing of bob changed
+	var aliases []models.Alias
+	if err := db.Unscoped().Where("user_id = ?", bob.user.ID).Order("id").Find(&aliases).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(aliases) != 2 || aliases[0].ID != bob.alias.ID || aliases[0].DeletedAt.Valid || aliases[0].EmailID != bob.email.ID || !aliases[1].DeletedAt.Valid {
+		t.Fatalf("aliases of bob = %+v", aliases)
+	}
+	var emails int64
+	if err := db.Model(&models.Email{}).Where("user_id = ?", bob.user.ID).Count(&emails).Error; err != nil {
+		t.Fatal(err)
+	}
+	if emails != 1 {
+		t.Fatalf("bob has %d emails, want 1", emails)
+	}
+}
+
+func TestAliasesCannotForwardToOtherEmails(t *testing.T) {
+	s, db := newTestEmailService(t)
+	alice := createAccount(t, db, "alice")
+	bob := createAccount(t, db, "bob")
+	ctx := as(&alice.user)
+
+	_, err := s.CreateAlias(ctx, &aliasme.CreateAliasRequest{EmailId: bob.email.ID, AliasPrefix: "mallory", Domain: "example.net"})
+	if err == nil {
+		t.Fatal("alias created towards the email of bob")
+	}
+	_, err = s.UpdateAlias(ctx, &aliasme.UpdateAliasRequest{Id: alice.alias.ID, EmailId: bob.email.ID})
+	if err == nil {
+		t.Fatal("alias moved towards the email of bob")
+	}
+
+	var aliases []models.Alias
+	if err := db.Where("email_id = ?", bob.email.ID).Find(&aliases).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(aliases) != 1 || aliases[0].ID != bob.alias.ID {
+		t.Fatalf("aliases towards bob = %+v", aliases)
+	}
+}
+
+func TestAdminsAccessEveryAlias(t *testing.T) {
+	s, db := newTestEmailService(t)
+	admin := createAccount(t, db, "admin")
+	if err := db.Model(&admin.user).Update("role", models.RoleAdmin).Error; err != nil {
+		t.Fatal(err)
+	}
+	bob := createAccount(t, db, "bob")
+	ctx := as(&admin.user)
+
+	listed, err := s.ListAliases(ctx, &aliasme.ListAliasesRequest{UserId: bob.user.ID})
+	if err != nil {
+		t.Fatalf("ListAliases: %v", err)
+	}
+	if len(listed.Aliases) != 1 || listed.Aliases[0].Id != bob.alias.ID {
+		t.Fatalf("aliases = %v, want the alias of bob", listed.Aliases)
+	}
+	if _, err := s.ListDeletedAliases(ctx, &aliasme.ListDeletedAliasesRequest{UserId: bob.user.ID}); err != nil {
+		t.Fatalf("ListDeletedAliases: %v", err)
+	}
+	if _, err := s.GetUsage(ctx, &aliasme.GetUsageRequest{UserId: bob.user.ID}); err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+}
+
+func TestAliasesWorkWithoutOVHClient(t *testing.T) {
+	s, db := newTestEmailService(t)
+	alice := createAccount(t, db, "alice")
+	ctx := as(&alice.user)
+
+	created, err := s.CreateAlias(ctx, &aliasme.CreateAliasRequest{EmailId: alice.email.ID, AliasPrefix: "news", Domain: "example.net"})
+	if err != nil {
+		t.Fatalf("CreateAlias: %v", err)
+	}
+	updated, err := s.UpdateAlias(ctx, &aliasme.UpdateAliasRequest{Id: created.Id, AliasPrefix: "letters"})
+	if err != nil {
+		t.Fatalf("UpdateAlias: %v", err)
+	}
+	if updated.AliasAddress != "letters@example.net" {
+		t.Fatalf("address = %s, want letters@example.net", updated.AliasAddress)
+	}
+	if _, err := s.DeleteAlias(ctx, &aliasme.DeleteAliasRequest{Id: created.Id}); err != nil {
+		t.Fatalf("DeleteAlias: %v", err)
+	}
+}
+
+func TestMovedAliasesNeedAFreeAddress(t *testing.T) {
+	s, db := newTestEmailService(t)
+	alice := createAccount(t, db, "alice")
+	bob := createAccount(t, db, "bob")
+	quarantined := models.QuarantinedAddress{Address: "carol@example.net", UserID: "carol-id", ReleaseAt: time.Now().Add(time.Hour)}
+	if err := db.Create(&quarantined).Error; err != nil {
+		t.Fatal(err)
+	}
+	ctx := as(&alice.user)
+
+	tests := []struct {
+		name   string
+		prefix string
+		want   codes.Code
+	}{
+		{"live alias of another user", "bob.shop", codes.AlreadyExists},
+		{"trashed alias of another user", "bob.old", codes.AlreadyExists},
+		{"trashed alias of the user", "alice.old", codes.AlreadyExists},
+		{"quarantined for another user", "carol", codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.UpdateAlias(ctx, &aliasme.UpdateAliasRequest{Id: alice.alias.ID, AliasPrefix: tt.prefix})
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	var stored models.Alias
+	if err := db.First(&stored, "id = ?", alice.alias.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.AliasAddress != alice.alias.AliasAddress {
+		t.Fatalf("alias moved to %s", stored.AliasAddress)
+	}
+	var other models.Alias
+	if err := db.First(&other, "id = ?", bob.alias.ID).Error; err != nil || other.UserID != bob.user.ID {
+		t.Fatalf("alias of bob = %+v, %v", other, err)
+	}
+}
+
+func TestMovedAliasesNeedThePlan(t *testing.T) {
+	s, db := newTestEmailService(t)
+	alice := createAccount(t, db, "alice")
+	website := models.Alias{
+		ID:           "alice-website",
+		UserID:       alice.user.ID,
+		EmailID:      alice.email.ID,
+		AliasAddress: "example.abcd@example.net",
+		Website:      "example.org",
+	}
+	if err := db.Create(&website).Error; err != nil {
+		t.Fatal(err)
+	}
+	ctx := as(&alice.user)
+
+	// The plan changed since the aliases were created
+	s.plans = plan.NewCatalog(plan.Config{
+		Default: "free",
+		Tiers:   map[string]plan.Plan{"free": {AllowedStrategies: []string{plan.StrategyWebsite}}},
+	})
+	_, err := s.UpdateAlias(ctx, &aliasme.UpdateAliasRequest{Id: website.ID, AliasPrefix: "custom"})
+	if got := status.Code(err); got != codes.ResourceExhausted {
+		t.Fatalf("custom prefix: code = %s, want ResourceExhausted", got)
+	}
+
+	s.plans = plan.NewCatalog(plan.Config{
+		Default: "free",
+		Tiers:   map[string]plan.Plan{"free": {AllowedDomains: []string{"example.com"}}},
+	})
+	_, err = s.UpdateAlias(ctx, &aliasme.UpdateAliasRequest{Id: alice.alias.ID, AliasPrefix: "alice.news"})
+	if got := status.Code(err); got != codes.ResourceExhausted {
+		t.Fatalf("domain: code = %s, want ResourceExhausted", got)
+	}
+
+	// Within the plan, at its alias limit
+	s.plans = plan.NewCatalog(plan.Config{
+		Default: "free",
+		Tiers:   map[string]plan.Plan{"free": {MaxAliases: 2}},
+	})
+	if _, err := s.UpdateAlias(ctx, &aliasme.UpdateAliasRequest{Id: alice.alias.ID, AliasPrefix: "alice.news"}); err != nil {
+		t.Fatalf("UpdateAlias within the plan: %v", err)
+	}
+}
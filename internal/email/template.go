@@ -0,0 +1,152 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// defaultLocale is used when no template exists for the requested locale
+const defaultLocale = "en"
+
+// Content is a rendered email
+type Content struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Templates renders email templates, looking up operator overrides in a
+// directory before the embedded ones. Templates are stored as
+// <locale>/<name>.subject.tmpl, <locale>/<name>.txt.tmpl and
+// <locale>/<name>.html.tmpl.
+type Templates struct {
+	sources       []fs.FS
+	defaultLocale string
+}
+
+// NewTemplates creates a template renderer. dir may be empty to only use the
+// embedded templates.
+func NewTemplates(dir, fallbackLocale string) *Templates {
+	embedded, _ := fs.Sub(embeddedTemplates, "templates")
+
+	sources := []fs.FS{embedded}
+	if dir != "" {
+		sources = []fs.FS{os.DirFS(dir), embedded}
+	}
+	if fallbackLocale == "" {
+		fallbackLocale = defaultLocale
+	}
+
+	return &Templates{sources: sources, defaultLocale: fallbackLocale}
+}
+
+// Render renders the named template in the closest available locale
+func (t *Templates) Render(name, locale string, data any) (*Content, error) {
+	locale = t.resolveLocale(name, locale)
+
+	subject, err := t.renderText(locale, name+".subject.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+	text, err := t.renderText(locale, name+".txt.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+	html, err := t.renderHTML(locale, name+".html.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Content{
+		Subject: strings.TrimSpace(subject),
+		Text:    text,
+		HTML:    html,
+	}, nil
+}
+
+// resolveLocale returns the first locale among "fr-FR", "fr" and the
+// default locale having the template
+func (t *Templates) resolveLocale(name, locale string) string {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	candidates := []string{locale}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		candidates = append(candidates, base)
+	}
+
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if _, err := t.read(path.Join(candidate, name+".txt.tmpl")); err == nil {
+			return candidate
+		}
+	}
+
+	return t.defaultLocale
+}
+
+// read returns the content of the first source having the file
+func (t *Templates) read(file string) (string, error) {
+	for _, source := range t.sources {
+		content, err := fs.ReadFile(source, file)
+		if err == nil {
+			return string(content), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("template %s: %w", file, fs.ErrNotExist)
+}
+
+// renderText renders a text/template file
+func (t *Templates) renderText(locale, file string, data any) (string, error) {
+	content, err := t.read(path.Join(locale, file))
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := texttemplate.New(file).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", file, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", file, err)
+	}
+
+	return buf.String(), nil
+}
+
+// renderHTML renders an html/template file
+func (t *Templates) renderHTML(locale, file string, data any) (string, error) {
+	content, err := t.read(path.Join(locale, file))
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := htmltemplate.New(file).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", file, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", file, err)
+	}
+
+	return buf.String(), nil
+}
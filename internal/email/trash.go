@@ -0,0 +1,203 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/plan"
+	"github.com/golgoth31/aliasme/internal/trash"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// ListDeletedAliases lists the deleted aliases of a user that can still be
+// restored
+func (s *EmailService) ListDeletedAliases(ctx context.Context, req *aliasme.ListDeletedAliasesRequest) (*aliasme.ListDeletedAliasesResponse, error) {
+	userID, err := auth.ActingUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID)
+	if s.config.TrashRetention > 0 {
+		query = query.Where("deleted_at >= ?", time.Now().Add(-s.config.TrashRetention))
+	}
+
+	var aliases []models.Alias
+	if err := query.Order("deleted_at DESC").Find(&aliases).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to list deleted aliases")
+		return nil, status.Error(codes.Internal, "failed to list deleted aliases")
+	}
+
+	protoAliases := make([]*aliasme.Alias, len(aliases))
+	for i, alias := range aliases {
+		protoAliases[i] = aliasToProto(&alias)
+	}
+
+	return &aliasme.ListDeletedAliasesResponse{
+		Aliases: protoAliases,
+	}, nil
+}
+
+// UndeleteAlias restores a deleted alias and re-provisions its OVH
+// redirection
+func (s *EmailService) UndeleteAlias(ctx context.Context, req *aliasme.UndeleteAliasRequest) (*aliasme.Alias, error) {
+	var alias models.Alias
+	if err := s.db.Unscoped().First(&alias, "id = ? AND deleted_at IS NOT NULL", req.Id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "deleted alias not found")
+		}
+		log.Error().Err(err).Msg("Failed to get deleted alias")
+		return nil, status.Error(codes.Internal, "failed to get deleted alias")
+	}
+	if err := auth.Authorize(ctx, alias.UserID); err != nil {
+		return nil, err
+	}
+
+	if !trash.Restorable(alias.DeletedAt.Time, s.config.TrashRetention) {
+		return nil, status.Error(codes.FailedPrecondition, "alias is past the trash retention window")
+	}
+
+	// The destination must still be a verified email of the user
+	var email models.Email
+	if err := s.db.First(&email, "id = ? AND user_id = ? AND verified = ?", alias.EmailID, alias.UserID, true).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to find verified email")
+		return nil, status.Error(codes.FailedPrecondition, "alias destination email is no longer verified")
+	}
+
+	prefix, domain := splitAddress(alias.AliasAddress)
+	strategy := plan.StrategyCustom
+	if alias.Website != "" {
+		strategy = plan.StrategyWebsite
+	}
+	if err := s.checkAliasQuota(alias.UserID, domain, strategy); err != nil {
+		return nil, err
+	}
+
+	deleted := alias
+	if err := s.db.Unscoped().Model(&alias).Update("deleted_at", nil).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, status.Errorf(codes.AlreadyExists, "an alias for %s already exists", alias.Website)
+		}
+		log.Error().Err(err).Msg("Failed to undelete alias")
+		return nil, status.Error(codes.Internal, "failed to undelete alias")
+	}
+	alias.DeletedAt = gorm.DeletedAt{}
+
+	// OVH is updated once the alias is restored, putting it back in the
+	// trash if it fails
+	if err := s.ovhClient.CreateEmailAlias(domain, prefix, email.Address); err != nil {
+		log.Error().Err(err).Msg("Failed to create alias in OVH")
+		if err := s.db.Unscoped().Save(&deleted).Error; err != nil {
+			log.Error().Err(err).Str("alias_id", deleted.ID).Msg("Failed to revert alias")
+		}
+		return nil, err
+	}
+
+	return aliasToProto(&alias), nil
+}
+
+// TrashUserAliases moves the aliases and emails of a deleted account to the
+// trash at the time of its deletion, within the transaction, tagged with the
+// deletion ID. Those already in the trash are left untouched. Their OVH
+// redirections are removed beforehand with RemoveUserRedirections.
+func (s *EmailService) TrashUserAliases(tx *gorm.DB, userID, deletionID string, deletedAt time.Time) error {
+	var aliases []models.Alias
+	if err := tx.Where("user_id = ?", userID).Find(&aliases).Error; err != nil {
+		return err
+	}
+
+	for _, model := range []any{&models.Alias{}, &models.Email{}} {
+		err := tx.Model(model).Where("user_id = ?", userID).
+			Updates(map[string]any{"deleted_at": deletedAt, "deletion_id": deletionID}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range aliases {
+		if err := s.quarantineAddress(tx, &aliases[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveUserRedirections removes the OVH redirections of the aliases of a
+// user about to be deleted, outside of any transaction. It returns the
+// aliases whose redirections were removed, even on failure, so the caller can
+// provision them again with ProvisionRedirections.
+func (s *EmailService) RemoveUserRedirections(userID string) ([]models.Alias, error) {
+	var aliases []models.Alias
+	if err := s.db.Where("user_id = ?", userID).Find(&aliases).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range aliases {
+		prefix, domain := splitAddress(aliases[i].AliasAddress)
+		if err := s.ovhClient.DeleteEmailAlias(domain, prefix); err != nil {
+			// The failed alias may have lost some of its redirections
+			return aliases[:i+1], err
+		}
+	}
+
+	return aliases, nil
+}
+
+// ProvisionRedirections provisions the OVH redirections of the aliases.
+// Existing redirections are updated rather than created twice, so it can be
+// retried safely.
+func (s *EmailService) ProvisionRedirections(aliases []models.Alias) error {
+	for i := range aliases {
+		var email models.Email
+		if err := s.db.Unscoped().First(&email, "id = ?", aliases[i].EmailID).Error; err != nil {
+			return err
+		}
+		prefix, domain := splitAddress(aliases[i].AliasAddress)
+		if err := s.ovhClient.UpdateEmailAlias(domain, prefix, email.Address); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProvisionUserRedirections provisions the OVH redirections of the aliases of
+// a restored user, after the restore is committed
+func (s *EmailService) ProvisionUserRedirections(userID string) error {
+	var aliases []models.Alias
+	if err := s.db.Where("user_id = ?", userID).Find(&aliases).Error; err != nil {
+		return err
+	}
+
+	return s.ProvisionRedirections(aliases)
+}
+
+// RestoreUserAliases restores the aliases and emails trashed with the
+// deletion of an account, within the transaction. Those deleted on their own
+// stay in the trash. Their OVH redirections are provisioned after the commit
+// with ProvisionUserRedirections.
+func (s *EmailService) RestoreUserAliases(tx *gorm.DB, userID, deletionID string) error {
+	// An empty ID would match the ones deleted on their own
+	if deletionID == "" {
+		return nil
+	}
+
+	for _, model := range []any{&models.Email{}, &models.Alias{}} {
+		err := tx.Unscoped().Model(model).
+			Where("user_id = ? AND deletion_id = ?", userID, deletionID).
+			Updates(map[string]any{"deleted_at": nil, "deletion_id": ""}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package email
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/plan"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// GetUsage reports the plan limits of a user and how much of them is used
+func (s *EmailService) GetUsage(ctx context.Context, req *aliasme.GetUsageRequest) (*aliasme.Usage, error) {
+	userID, err := auth.ActingUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.userPlan(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.countOwned(&models.Alias{}, userID)
+	if err != nil {
+		return nil, err
+	}
+	emails, err := s.countOwned(&models.Email{}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &aliasme.Usage{
+		Plan:              p.Name,
+		Aliases:           int32(aliases),
+		MaxAliases:        int32(p.MaxAliases),
+		Emails:            int32(emails),
+		MaxEmails:         int32(p.MaxEmails),
+		AllowedDomains:    p.AllowedDomains,
+		AllowedStrategies: p.AllowedStrategies,
+	}, nil
+}
+
+// checkAliasQuota returns a ResourceExhausted error if the user's plan does
+// not allow aliases on the domain or with the strategy, or one more alias
+func (s *EmailService) checkAliasQuota(userID, domain, strategy string) error {
+	p, err := s.checkAliasPlan(userID, domain, strategy)
+	if err != nil {
+		return err
+	}
+	if p.MaxAliases <= 0 {
+		return nil
+	}
+
+	count, err := s.countOwned(&models.Alias{}, userID)
+	if err != nil {
+		return err
+	}
+	if count >= int64(p.MaxAliases) {
+		return status.Errorf(codes.ResourceExhausted, "plan %s is limited to %d aliases", p.Name, p.MaxAliases)
+	}
+
+	return nil
+}
+
+// checkAliasPlan returns the user's plan, or a ResourceExhausted error if it
+// does not allow aliases on the domain or with the strategy
+func (s *EmailService) checkAliasPlan(userID, domain, strategy string) (plan.Plan, error) {
+	p, err := s.userPlan(userID)
+	if err != nil {
+		return plan.Plan{}, err
+	}
+
+	if !p.AllowsDomain(domain) {
+		return plan.Plan{}, status.Errorf(codes.ResourceExhausted, "plan %s does not allow aliases on %s", p.Name, domain)
+	}
+	if !p.AllowsStrategy(strategy) {
+		return plan.Plan{}, status.Errorf(codes.ResourceExhausted, "plan %s does not allow %s aliases", p.Name, strategy)
+	}
+
+	return p, nil
+}
+
+// checkEmailQuota returns a ResourceExhausted error if the user's plan does
+// not allow one more destination email
+func (s *EmailService) checkEmailQuota(userID string) error {
+	p, err := s.userPlan(userID)
+	if err != nil {
+		return err
+	}
+	if p.MaxEmails <= 0 {
+		return nil
+	}
+
+	count, err := s.countOwned(&models.Email{}, userID)
+	if err != nil {
+		return err
+	}
+	if count >= int64(p.MaxEmails) {
+		return status.Errorf(codes.ResourceExhausted, "plan %s is limited to %d emails", p.Name, p.MaxEmails)
+	}
+
+	return nil
+}
+
+// userPlan returns the plan assigned to the user
+func (s *EmailService) userPlan(userID string) (plan.Plan, error) {
+	var user models.User
+	if err := s.db.First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return plan.Plan{}, status.Error(codes.NotFound, "user not found")
+		}
+		log.Error().Err(err).Msg("Failed to get user")
+		return plan.Plan{}, status.Error(codes.Internal, "failed to get user")
+	}
+
+	return s.plans.Get(user.Plan), nil
+}
+
+// countOwned counts the records of the model owned by the user
+func (s *EmailService) countOwned(model any, userID string) (int64, error) {
+	var count int64
+	if err := s.db.Model(model).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to count user resources")
+		return 0, status.Error(codes.Internal, "failed to count user resources")
+	}
+
+	return count, nil
+}
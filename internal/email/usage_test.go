@@ -0,0 +1,54 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/plan"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPlanLimitsExhaustResources(t *testing.T) {
+	s, db := newTestEmailService(t)
+	s.config.Domains = []string{"example.net", "example.com"}
+	s.plans = plan.NewCatalog(plan.Config{
+		Default: "free",
+		Tiers: map[string]plan.Plan{"free": {
+			MaxAliases:        2,
+			AllowedDomains:    []string{"example.net"},
+			AllowedStrategies: []string{plan.StrategyCustom},
+		}},
+	})
+	// Has one alias already
+	alice := createAccount(t, db, "alice")
+	ctx := as(&alice.user)
+
+	calls := map[string]func() error{
+		"domain": func() error {
+			_, err := s.CreateAlias(ctx, &aliasme.CreateAliasRequest{EmailId: alice.email.ID, AliasPrefix: "shop", Domain: "example.com"})
+			return err
+		},
+		"strategy": func() error {
+			_, err := s.QuickCreateAlias(ctx, &aliasme.QuickCreateAliasRequest{EmailId: alice.email.ID, Hostname: "example.org"})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if got := status.Code(call()); got != codes.ResourceExhausted {
+				t.Fatalf("code = %s, want ResourceExhausted", got)
+			}
+		})
+	}
+
+	news := models.Alias{ID: "alice-news", UserID: alice.user.ID, EmailID: alice.email.ID, AliasAddress: "alice.news@example.net"}
+	if err := db.Create(&news).Error; err != nil {
+		t.Fatal(err)
+	}
+	_, err := s.CreateAlias(ctx, &aliasme.CreateAliasRequest{EmailId: alice.email.ID, AliasPrefix: "more", Domain: "example.net"})
+	if got := status.Code(err); got != codes.ResourceExhausted {
+		t.Fatalf("alias beyond the limit: code = %s, want ResourceExhausted", got)
+	}
+}
@@ -0,0 +1,205 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/utils"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// verificationCodeLength is the number of digits of verification codes
+const verificationCodeLength = 6
+
+// ResendVerification sends a new verification link or code for an unverified
+// email, at most once per configured resend interval
+func (s *EmailService) ResendVerification(ctx context.Context, req *aliasme.ResendVerificationRequest) (*aliasme.ResendVerificationResponse, error) {
+	var email models.Email
+	if err := s.db.First(&email, "id = ?", req.EmailId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "email not found")
+		}
+		log.Error().Err(err).Msg("Failed to get email")
+		return nil, status.Error(codes.Internal, "failed to get email")
+	}
+	if err := auth.Authorize(ctx, email.UserID); err != nil {
+		return nil, err
+	}
+
+	if email.Verified {
+		return nil, status.Error(codes.FailedPrecondition, "email is already verified")
+	}
+
+	// Wrong codes count across resends, so the limit caps guesses for good
+	if req.VerificationMethod == aliasme.VerificationMethod_VERIFICATION_METHOD_CODE && s.codeAttemptsExhausted(&email) {
+		return nil, status.Error(codes.ResourceExhausted, "too many wrong codes, verify with a link instead")
+	}
+
+	now := time.Now()
+	interval := s.emailService.config.ResendInterval
+	if email.TokenIssuedAt != nil && interval > 0 {
+		if next := email.TokenIssuedAt.Add(interval); now.Before(next) {
+			return nil, status.Errorf(codes.ResourceExhausted, "verification email already sent, retry after %s",
+				next.Format(time.RFC3339))
+		}
+	}
+
+	token, err := s.newVerification(&email, req.VerificationMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := s.userLocale(email.UserID)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&email).Error; err != nil {
+			log.Error().Err(err).Msg("Failed to update email")
+			return err
+		}
+
+		return s.sendVerification(tx, &email, token, locale)
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to queue verification email")
+	}
+
+	ttl := s.emailService.config.TokenTTL
+	if email.CodeHash != "" {
+		ttl = s.emailService.config.CodeTTL
+	}
+
+	return &aliasme.ResendVerificationResponse{
+		ExpiresAt: timestamppb.New(email.TokenIssuedAt.Add(ttl)),
+	}, nil
+}
+
+// verifyCode verifies an email address with the code sent to it. Each try
+// counts against the attempt limit before the code is compared.
+func (s *EmailService) verifyCode(emailID, code string) (*aliasme.Email, error) {
+	if emailID == "" || code == "" {
+		return nil, status.Error(codes.InvalidArgument, "email_id and code are required")
+	}
+
+	var email models.Email
+	if err := s.db.First(&email, "id = ?", emailID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "email not found")
+		}
+		log.Error().Err(err).Msg("Failed to get email")
+		return nil, status.Error(codes.Internal, "failed to get email")
+	}
+
+	if email.Verified {
+		return nil, status.Error(codes.FailedPrecondition, "email is already verified")
+	}
+	if email.CodeHash == "" {
+		return nil, status.Error(codes.FailedPrecondition, "no verification code was sent for this email")
+	}
+	if s.verificationExpired(&email, s.emailService.config.CodeTTL) {
+		return nil, status.Error(codes.DeadlineExceeded, "verification code has expired")
+	}
+
+	// Reserve the attempt atomically so concurrent tries cannot exceed the
+	// limit
+	query := s.db.Model(&models.Email{}).Where("id = ? AND code_hash = ?", email.ID, email.CodeHash)
+	if max := s.emailService.config.MaxCodeAttempts; max > 0 {
+		query = query.Where("code_attempts < ?", max)
+	}
+	result := query.UpdateColumn("code_attempts", gorm.Expr("code_attempts + 1"))
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Failed to record verification attempt")
+		return nil, status.Error(codes.Internal, "failed to record verification attempt")
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Error(codes.ResourceExhausted, "too many wrong codes, verify with a link instead")
+	}
+
+	if !utils.TokenMatches(s.emailService.config.Secret, code, email.CodeHash) {
+		return nil, status.Error(codes.InvalidArgument, "invalid verification code")
+	}
+
+	email.Verified = true
+	email.TokenHash = ""
+	email.CodeHash = ""
+	email.CodeAttempts = 0
+	email.UpdatedAt = time.Now()
+
+	if err := s.db.Save(&email).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to update email")
+		return nil, status.Error(codes.Internal, "failed to update email")
+	}
+
+	return emailToProto(&email), nil
+}
+
+// codeAttemptsExhausted reports whether the email used up its wrong codes
+func (s *EmailService) codeAttemptsExhausted(email *models.Email) bool {
+	max := s.emailService.config.MaxCodeAttempts
+	return max > 0 && email.CodeAttempts >= max
+}
+
+// newVerification replaces the pending verification of the email with a new
+// link token or code, depending on the method. Only the hash is kept on the
+// email; the returned token or code is meant for sendVerification. The wrong
+// code attempts are kept, as they count for the email rather than the code.
+func (s *EmailService) newVerification(email *models.Email, method aliasme.VerificationMethod) (string, error) {
+	now := time.Now()
+	email.TokenHash = ""
+	email.CodeHash = ""
+	email.TokenIssuedAt = &now
+	email.UpdatedAt = now
+
+	secret := s.emailService.config.Secret
+	switch method {
+	case aliasme.VerificationMethod_VERIFICATION_METHOD_UNSPECIFIED, aliasme.VerificationMethod_VERIFICATION_METHOD_LINK:
+		token, err := generateToken()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to generate verification token")
+			return "", err
+		}
+		email.TokenHash = utils.HashToken(secret, token)
+		return token, nil
+	case aliasme.VerificationMethod_VERIFICATION_METHOD_CODE:
+		code := utils.GenerateRandomNumber(verificationCodeLength)
+		email.CodeHash = utils.HashToken(secret, code)
+		return code, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unknown verification method %q", method)
+	}
+}
+
+// sendVerification queues the email carrying the pending link or code
+func (s *EmailService) sendVerification(tx *gorm.DB, email *models.Email, token, locale string) error {
+	if email.CodeHash != "" {
+		return s.emailService.SendVerificationCode(tx, email.Address, token, locale)
+	}
+
+	return s.emailService.SendVerificationEmail(tx, email.Address, token, locale)
+}
+
+// userLocale returns the preferred locale of a user, empty if unknown
+func (s *EmailService) userLocale(userID string) string {
+	var user models.User
+	if err := s.db.Select("locale").First(&user, "id = ?", userID).Error; err != nil {
+		return ""
+	}
+
+	return user.Locale
+}
+
+// verificationExpired reports whether the verification token or code of the
+// email is past the TTL. Both are issued with TokenIssuedAt.
+func (s *EmailService) verificationExpired(email *models.Email, ttl time.Duration) bool {
+	if ttl <= 0 {
+		return false
+	}
+
+	return time.Since(*email.TokenIssuedAt) > ttl
+}
@@ -0,0 +1,221 @@
+// Package export builds the personal data bundle handed to users on request.
+//
+// The bundle schema is versioned by SchemaVersion. Fields are only ever added
+// within a version; renaming or removing one requires a new version.
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"gorm.io/gorm"
+)
+
+// SchemaVersion is the version of the bundle schema produced by this package
+const SchemaVersion = 1
+
+// ErrUserNotFound is returned when the exported user does not exist
+var ErrUserNotFound = errors.New("user not found")
+
+// Manifest describes an export bundle
+type Manifest struct {
+	SchemaVersion int       `json:"schema_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+	UserID        string    `json:"user_id"`
+}
+
+// User is the exported profile of the user
+type User struct {
+	ID            string     `json:"id"`
+	Username      string     `json:"username"`
+	Email         string     `json:"email"`
+	Plan          string     `json:"plan"`
+	Locale        string     `json:"locale"`
+	Notifications string     `json:"notifications"`
+	Role          string     `json:"role"`
+	TOTPEnabled   bool       `json:"totp_enabled"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Email is an exported destination email address
+type Email struct {
+	ID             string     `json:"id"`
+	Address        string     `json:"address"`
+	Verified       bool       `json:"verified"`
+	PGPFingerprint string     `json:"pgp_fingerprint,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Alias is an exported alias, including deleted ones
+type Alias struct {
+	ID           string     `json:"id"`
+	EmailID      string     `json:"email_id"`
+	AliasAddress string     `json:"alias_address"`
+	Website      string     `json:"website,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+}
+
+// AddressHistory is an alias address released by the user and still
+// reserved for them
+type AddressHistory struct {
+	Address    string    `json:"address"`
+	ReleasedAt time.Time `json:"released_at"`
+	ReservedTo time.Time `json:"reserved_until"`
+}
+
+// Bundle holds everything stored about a user
+type Bundle struct {
+	Manifest
+	User           User             `json:"user"`
+	Emails         []Email          `json:"emails"`
+	Aliases        []Alias          `json:"aliases"`
+	AddressHistory []AddressHistory `json:"address_history"`
+}
+
+// Collect gathers the data stored about a user, including soft-deleted records
+func Collect(db *gorm.DB, userID string) (*Bundle, error) {
+	var user models.User
+	if err := db.Unscoped().First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	var emails []models.Email
+	if err := db.Unscoped().Order("created_at").Find(&emails, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+
+	var aliases []models.Alias
+	if err := db.Unscoped().Order("created_at").Find(&aliases, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+
+	var history []models.QuarantinedAddress
+	if err := db.Order("created_at").Find(&history, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{
+		Manifest: Manifest{
+			SchemaVersion: SchemaVersion,
+			ExportedAt:    time.Now().UTC(),
+			UserID:        user.ID,
+		},
+		User: User{
+			ID:            user.ID,
+			Username:      user.Username,
+			Email:         user.Email,
+			Plan:          user.Plan,
+			Locale:        user.Locale,
+			Notifications: user.Notifications,
+			Role:          user.Role,
+			TOTPEnabled:   user.TOTPEnabled,
+			CreatedAt:     user.CreatedAt,
+			UpdatedAt:     user.UpdatedAt,
+			DeletedAt:     deletedAt(user.DeletedAt),
+		},
+		Emails:         make([]Email, len(emails)),
+		Aliases:        make([]Alias, len(aliases)),
+		AddressHistory: make([]AddressHistory, len(history)),
+	}
+
+	for i, email := range emails {
+		bundle.Emails[i] = Email{
+			ID:             email.ID,
+			Address:        email.Address,
+			Verified:       email.Verified,
+			PGPFingerprint: email.PGPFingerprint,
+			CreatedAt:      email.CreatedAt,
+			UpdatedAt:      email.UpdatedAt,
+			DeletedAt:      deletedAt(email.DeletedAt),
+		}
+	}
+	for i, alias := range aliases {
+		bundle.Aliases[i] = Alias{
+			ID:           alias.ID,
+			EmailID:      alias.EmailID,
+			AliasAddress: alias.AliasAddress,
+			Website:      alias.Website,
+			CreatedAt:    alias.CreatedAt,
+			UpdatedAt:    alias.UpdatedAt,
+			DeletedAt:    deletedAt(alias.DeletedAt),
+		}
+	}
+	for i, entry := range history {
+		bundle.AddressHistory[i] = AddressHistory{
+			Address:    entry.Address,
+			ReleasedAt: entry.CreatedAt,
+			ReservedTo: entry.ReleaseAt,
+		}
+	}
+
+	return bundle, nil
+}
+
+// WriteJSON writes the bundle as a single indented JSON document
+func WriteJSON(w io.Writer, bundle *Bundle) error {
+	return writeJSON(w, bundle)
+}
+
+// WriteZIP writes the bundle as a ZIP archive holding one JSON file per
+// section: manifest.json, user.json, emails.json, aliases.json and
+// address_history.json
+func WriteZIP(w io.Writer, bundle *Bundle) error {
+	archive := zip.NewWriter(w)
+
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"manifest.json", bundle.Manifest},
+		{"user.json", bundle.User},
+		{"emails.json", bundle.Emails},
+		{"aliases.json", bundle.Aliases},
+		{"address_history.json", bundle.AddressHistory},
+	}
+
+	for _, file := range files {
+		f, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: bundle.ExportedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if err := writeJSON(f, file.content); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// writeJSON writes v as indented JSON
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+// deletedAt returns the deletion time of a soft-deleted record
+func deletedAt(value gorm.DeletedAt) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Time
+}
@@ -8,34 +8,229 @@ import (
 
 // User represents a user in the system
 type User struct {
-	ID        string         `gorm:"primaryKey" json:"id"`
-	Username  string         `gorm:"uniqueIndex" json:"username"`
-	Email     string         `gorm:"uniqueIndex" json:"email"`
-	Password  string         `json:"-"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            string `gorm:"primaryKey" json:"id"`
+	Username      string `gorm:"uniqueIndex" json:"username"`
+	Email         string `gorm:"uniqueIndex" json:"email"`
+	Password      string `json:"-"`
+	Plan          string `json:"plan"`
+	Locale        string `json:"locale"`
+	Notifications string `json:"notifications"`
+	Role          string `gorm:"not null;default:user" json:"role"`
+	// TOTPSecret is the encrypted TOTP secret, set from enrolment on
+	TOTPSecret  string `json:"-"`
+	TOTPEnabled bool   `json:"totp_enabled"`
+	// TOTPLastStep is the time step of the last accepted code, which cannot
+	// be used again
+	TOTPLastStep int64 `json:"-"`
+	// TOTPFailures counts the invalid two-factor codes since the last valid
+	// one, locking second factor checks until TOTPLockedUntil past the limit
+	TOTPFailures    int        `json:"-"`
+	TOTPLockedUntil *time.Time `json:"-"`
+	// RecoveryCodes holds the space-separated hashes of the unused recovery
+	// codes
+	RecoveryCodes string `json:"-"`
+	// OIDCIssuer and OIDCSubject identify the single sign-on account of the
+	// user, if any
+	OIDCIssuer  string `gorm:"column:oidc_issuer" json:"oidc_issuer,omitempty"`
+	OIDCSubject string `gorm:"column:oidc_subject;index" json:"-"`
+	// RestoreTokenHash is the hash of the token of the link restoring the
+	// deleted account
+	RestoreTokenHash string `gorm:"index" json:"-"`
+	// DeletionID identifies the deletion of the account, tagging the emails
+	// and aliases trashed with it
+	DeletionID string         `gorm:"index" json:"-"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // Email represents a registered email address
 type Email struct {
-	ID        string         `gorm:"primaryKey" json:"id"`
-	UserID    string         `gorm:"index" json:"user_id"`
-	Address   string         `gorm:"uniqueIndex" json:"address"`
-	Verified  bool           `json:"verified"`
-	Token     string         `json:"-"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID             string     `gorm:"primaryKey" json:"id"`
+	UserID         string     `gorm:"index" json:"user_id"`
+	Address        string     `gorm:"uniqueIndex" json:"address"`
+	Verified       bool       `json:"verified"`
+	TokenHash      string     `gorm:"index" json:"-"`
+	TokenIssuedAt  *time.Time `json:"-"`
+	CodeHash       string     `json:"-"`
+	CodeAttempts   int        `json:"-"`
+	PGPKey         string     `json:"-"`
+	PGPFingerprint string     `json:"pgp_fingerprint,omitempty"`
+	// DeletionID is the deletion of the account which trashed the email, if
+	// any
+	DeletionID string         `gorm:"index" json:"-"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // Alias represents an email alias
 type Alias struct {
-	ID           string         `gorm:"primaryKey" json:"id"`
-	UserID       string         `gorm:"index" json:"user_id"`
-	EmailID      string         `gorm:"index" json:"email_id"`
-	AliasAddress string         `gorm:"uniqueIndex" json:"alias_address"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	ID           string `gorm:"primaryKey" json:"id"`
+	UserID       string `gorm:"index" json:"user_id"`
+	EmailID      string `gorm:"index" json:"email_id"`
+	AliasAddress string `gorm:"uniqueIndex" json:"alias_address"`
+	// Website is the hostname of a website alias, unique among the live
+	// aliases of the user
+	Website string `gorm:"index" json:"website"`
+	// DeletionID is the deletion of the account which trashed the alias, if
+	// any
+	DeletionID string         `gorm:"index" json:"-"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// QuarantinedAddress records a deleted alias address that cannot be reissued
+// to another user before ReleaseAt
+type QuarantinedAddress struct {
+	Address   string    `gorm:"primaryKey" json:"address"`
+	UserID    string    `gorm:"index" json:"user_id"`
+	ReleaseAt time.Time `gorm:"index" json:"release_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Outgoing mail statuses
+const (
+	MailStatusPending = "pending"
+	MailStatusSent    = "sent"
+	MailStatusDead    = "dead"
+)
+
+// OutgoingMail is a message queued for delivery
+type OutgoingMail struct {
+	ID            string     `gorm:"primaryKey" json:"id"`
+	Sender        string     `json:"sender"`
+	Recipient     string     `gorm:"index" json:"recipient"`
+	Subject       string     `json:"subject"`
+	Message       []byte     `json:"-"`
+	Status        string     `gorm:"index" json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error"`
+	NextAttemptAt time.Time  `gorm:"index" json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// Roles of users. Admins may access the resources of every user.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Notification modes of users, an empty mode being immediate
+const (
+	NotificationsImmediate = "immediate"
+	NotificationsDigest    = "digest"
+	NotificationsOff       = "off"
+)
+
+// Alias lifecycle events notified to their owner
+const (
+	EventAliasCreated     = "alias_created"
+	EventAliasTransferred = "alias_transferred"
+	EventAliasDisabled    = "alias_disabled"
+	EventAliasExpiring    = "alias_expiring"
+)
+
+// Notification is an alias event reported to its owner. Notifications of
+// users receiving a digest stay unsent until the next digest.
+type Notification struct {
+	ID           string `gorm:"primaryKey" json:"id"`
+	UserID       string `gorm:"index" json:"user_id"`
+	AliasID      string `gorm:"index" json:"alias_id"`
+	Event        string `json:"event"`
+	AliasAddress string `json:"alias_address"`
+	Destination  string `json:"destination,omitempty"`
+	// Recipient is the verified address the alias forwards to, where the
+	// notification is sent
+	Recipient string     `json:"-"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	SentAt    *time.Time `gorm:"index" json:"sent_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Session is a login of a user, renewed with its refresh token until it
+// expires or is revoked
+type Session struct {
+	ID               string     `gorm:"primaryKey" json:"id"`
+	UserID           string     `gorm:"index" json:"user_id"`
+	RefreshTokenHash string     `gorm:"uniqueIndex" json:"-"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// PasswordReset is a single-use token emailed to reset a password
+type PasswordReset struct {
+	ID        string     `gorm:"primaryKey" json:"id"`
+	UserID    string     `gorm:"index" json:"user_id"`
+	TokenHash string     `gorm:"uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PersonalAccessToken is a long-lived credential restricted to scopes, for
+// scripts and extensions
+type PersonalAccessToken struct {
+	ID         string     `gorm:"primaryKey" json:"id"`
+	UserID     string     `gorm:"index" json:"user_id"`
+	Name       string     `json:"name"`
+	TokenHash  string     `gorm:"uniqueIndex" json:"-"`
+	Scopes     string     `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// WebAuthnCredential is a passkey or security key registered by a user
+type WebAuthnCredential struct {
+	ID     string `gorm:"primaryKey" json:"id"`
+	UserID string `gorm:"index" json:"user_id"`
+	Name   string `json:"name"`
+	// CredentialID is the base64url credential ID chosen by the authenticator
+	CredentialID string `gorm:"uniqueIndex" json:"-"`
+	// Credential is the JSON encoded public key, flags and signature counter
+	Credential string     `json:"-"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// WebAuthnChallenge is the server state of a pending WebAuthn ceremony, used
+// once
+type WebAuthnChallenge struct {
+	ID string `gorm:"primaryKey" json:"id"`
+	// UserID is empty for passkey logins, where the authenticator picks the
+	// user
+	UserID    string    `gorm:"index" json:"user_id"`
+	Ceremony  string    `json:"ceremony"`
+	Data      string    `json:"-"`
+	ExpiresAt time.Time `gorm:"index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Device login states
+const (
+	DeviceLoginPending  = "pending"
+	DeviceLoginApproved = "approved"
+	DeviceLoginDenied   = "denied"
+)
+
+// DeviceLogin is a pending OAuth 2.0 device authorization, approved by the
+// user in a browser and polled by the client until then
+type DeviceLogin struct {
+	ID             string `gorm:"primaryKey" json:"id"`
+	DeviceCodeHash string `gorm:"uniqueIndex" json:"-"`
+	// UserCode is the code the user enters, stored without separator
+	UserCode string `gorm:"uniqueIndex" json:"-"`
+	// UserID is set once the login is approved
+	UserID       string     `gorm:"index" json:"user_id"`
+	Status       string     `json:"status"`
+	LastPolledAt *time.Time `json:"last_polled_at,omitempty"`
+	ExpiresAt    time.Time  `gorm:"index" json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
 }
@@ -2,6 +2,7 @@ package ovh
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/golgoth31/aliasme/internal/utils"
 	"github.com/ovh/go-ovh/ovh"
@@ -63,16 +64,94 @@ func (c *Client) CreateAlias(domain, prefix, destination string) (*Alias, error)
 	}, err
 }
 
-// CreateEmailAlias creates a new email alias
+// CreateEmailAlias creates a redirection from alias@domain to target. Without
+// a client, it is only logged.
 func (c *Client) CreateEmailAlias(domain, alias, target string) error {
-	// Implementation will depend on the specific OVH API endpoints
-	// This is a placeholder for the actual implementation
+	if c == nil {
+		skipped("create", domain, alias)
+		return nil
+	}
+
+	redirection := &aliasesPost{
+		From:      alias + "@" + domain,
+		LocalCopy: false,
+		To:        target,
+	}
+
+	if err := c.client.Post(redirectionPath(domain), redirection, nil); err != nil {
+		return fmt.Errorf("failed to create redirection for %s: %w", redirection.From, err)
+	}
+
+	return nil
+}
+
+// UpdateEmailAlias points the redirections of alias@domain to target,
+// creating the redirection if it does not exist. Without a client, it is only
+// logged.
+func (c *Client) UpdateEmailAlias(domain, alias, target string) error {
+	if c == nil {
+		skipped("update", domain, alias)
+		return nil
+	}
+
+	ids, err := c.redirectionIDs(domain, alias)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return c.CreateEmailAlias(domain, alias, target)
+	}
+
+	for _, id := range ids {
+		path := redirectionPath(domain) + "/" + url.PathEscape(id) + "/changeRedirection"
+		if err := c.client.Post(path, &redirectionChange{To: target}, nil); err != nil {
+			return fmt.Errorf("failed to update redirection %s: %w", id, err)
+		}
+	}
+
 	return nil
 }
 
-// DeleteEmailAlias deletes an email alias
+// DeleteEmailAlias deletes the redirections of alias@domain. Without a
+// client, it is only logged.
 func (c *Client) DeleteEmailAlias(domain, alias string) error {
-	// Implementation will depend on the specific OVH API endpoints
-	// This is a placeholder for the actual implementation
+	if c == nil {
+		skipped("delete", domain, alias)
+		return nil
+	}
+
+	ids, err := c.redirectionIDs(domain, alias)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := c.client.Delete(redirectionPath(domain)+"/"+url.PathEscape(id), nil); err != nil {
+			return fmt.Errorf("failed to delete redirection %s: %w", id, err)
+		}
+	}
+
 	return nil
 }
+
+// skipped logs a redirection change skipped for lack of a configured client
+func skipped(action, domain, alias string) {
+	log.Warn().Str("alias", alias+"@"+domain).Str("action", action).Msg("OVH client is not configured, skipping redirection change")
+}
+
+// redirectionIDs lists the IDs of the redirections from alias@domain
+func (c *Client) redirectionIDs(domain, alias string) ([]string, error) {
+	query := url.Values{"from": {alias + "@" + domain}}
+
+	var ids []string
+	if err := c.client.Get(redirectionPath(domain)+"?"+query.Encode(), &ids); err != nil {
+		return nil, fmt.Errorf("failed to list redirections for %s@%s: %w", alias, domain, err)
+	}
+
+	return ids, nil
+}
+
+// redirectionPath returns the API path of the domain redirections
+func redirectionPath(domain string) string {
+	return "/email/domain/" + url.PathEscape(domain) + "/redirection"
+}
@@ -5,3 +5,7 @@ type aliasesPost struct {
 	LocalCopy bool   `json:"localCopy"`
 	To        string `json:"to"`
 }
+
+type redirectionChange struct {
+	To string `json:"to"`
+}
@@ -0,0 +1,355 @@
+package passkey
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/validation"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxNameLength is the maximum length of credential names
+const maxNameLength = 64
+
+// beginResponse starts a ceremony: the client passes PublicKey to the
+// navigator.credentials API and answers with the challenge ID
+type beginResponse struct {
+	ChallengeID string `json:"challengeId"`
+	PublicKey   any    `json:"publicKey"`
+}
+
+type finishRegistrationRequest struct {
+	ChallengeID string          `json:"challengeId"`
+	Name        string          `json:"name"`
+	Credential  json.RawMessage `json:"credential"`
+}
+
+type beginLoginRequest struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+type finishLoginRequest struct {
+	ChallengeID string          `json:"challengeId"`
+	Credential  json.RawMessage `json:"credential"`
+	// UserCode approves the device login of the code instead of opening a
+	// session
+	UserCode string `json:"userCode"`
+}
+
+// credentialResponse describes a registered credential
+type credentialResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// identityKey is the echo context key of the authenticated identity
+const identityKey = "passkey.identity"
+
+// RequireSession authenticates the requests with the access token of a login
+// session
+func (s *Service) RequireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		scheme, token, found := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authorization must be a bearer token")
+		}
+
+		identity, err := s.sessions.AuthenticateSession(strings.TrimSpace(token))
+		if err != nil {
+			return statusError(err)
+		}
+		c.Set(identityKey, identity)
+
+		return next(c)
+	}
+}
+
+// BeginRegistration starts registering a new credential for the
+// authenticated user
+func (s *Service) BeginRegistration(c echo.Context) error {
+	identity := c.Get(identityKey).(*auth.Identity)
+
+	u, err := s.loadUser(identity.UserID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get user")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user")
+	}
+
+	// Discoverable credentials can log in without a username
+	creation, session, err := s.webauthn.BeginRegistration(u,
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
+		webauthn.WithExclusions(webauthn.Credentials(u.credentials).CredentialDescriptors()),
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin WebAuthn registration")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to begin registration")
+	}
+
+	challengeID, err := s.saveChallenge(u.model.ID, ceremonyRegistration, session)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to save WebAuthn challenge")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to begin registration")
+	}
+
+	return c.JSON(http.StatusOK, beginResponse{ChallengeID: challengeID, PublicKey: creation.Response})
+}
+
+// FinishRegistration verifies the new credential and stores it
+func (s *Service) FinishRegistration(c echo.Context) error {
+	identity := c.Get(identityKey).(*auth.Identity)
+
+	var req finishRegistrationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = "Passkey"
+	}
+	if len(name) > maxNameLength {
+		return echo.NewHTTPError(http.StatusBadRequest, "name is too long")
+	}
+
+	challenge, session, err := s.takeChallenge(req.ChallengeID, ceremonyRegistration)
+	if err != nil {
+		return challengeError(err)
+	}
+	if challenge.UserID != identity.UserID {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid or expired challenge")
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(req.Credential)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid credential")
+	}
+	u, err := s.loadUser(identity.UserID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get user")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user")
+	}
+	credential, err := s.webauthn.CreateCredential(u, *session, parsed)
+	if err != nil {
+		log.Debug().Str("details", protocolDetails(err)).Msg("WebAuthn registration rejected")
+		return echo.NewHTTPError(http.StatusBadRequest, "credential verification failed")
+	}
+
+	data, err := json.Marshal(credential)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode credential")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to register credential")
+	}
+	record := &models.WebAuthnCredential{
+		ID:           xid.New().String(),
+		UserID:       u.model.ID,
+		Name:         name,
+		CredentialID: encodeCredentialID(credential.ID),
+		Credential:   string(data),
+		CreatedAt:    time.Now(),
+	}
+	if err := s.db.Create(record).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to save credential")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to register credential")
+	}
+
+	return c.JSON(http.StatusCreated, credentialToResponse(record))
+}
+
+// ListCredentials lists the credentials of the authenticated user
+func (s *Service) ListCredentials(c echo.Context) error {
+	identity := c.Get(identityKey).(*auth.Identity)
+
+	var records []models.WebAuthnCredential
+	if err := s.db.Where("user_id = ?", identity.UserID).Order("created_at DESC").Find(&records).Error; err != nil {
+		log.Error().Err(err).Msg("Failed to list credentials")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list credentials")
+	}
+
+	credentials := make([]credentialResponse, len(records))
+	for i := range records {
+		credentials[i] = credentialToResponse(&records[i])
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"credentials": credentials})
+}
+
+// DeleteCredential removes a credential of the authenticated user
+func (s *Service) DeleteCredential(c echo.Context) error {
+	identity := c.Get(identityKey).(*auth.Identity)
+
+	result := s.db.Where("id = ? AND user_id = ?", c.Param("id"), identity.UserID).Delete(&models.WebAuthnCredential{})
+	if result.Error != nil {
+		log.Error().Err(result.Error).Msg("Failed to delete credential")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete credential")
+	}
+	if result.RowsAffected == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "credential not found")
+	}
+
+	return c.JSON(http.StatusOK, map[string]bool{"success": true})
+}
+
+// BeginLogin starts a login. Without a password the passkey is the only
+// factor and the authenticator picks the account; with a login and password,
+// it is the second factor of that user.
+func (s *Service) BeginLogin(c echo.Context) error {
+	var req beginLoginRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	var (
+		userID    string
+		ceremony  = ceremonyLogin
+		assertion *protocol.CredentialAssertion
+		session   *webauthn.SessionData
+		err       error
+	)
+	if req.Password == "" {
+		// User verification makes the passkey count as two factors
+		assertion, session, err = s.webauthn.BeginDiscoverableLogin(
+			webauthn.WithUserVerification(protocol.VerificationRequired),
+		)
+	} else {
+		// Same rules as the Login RPC, these routes bypass the interceptor
+		if verr := validation.Validate(&aliasme.LoginRequest{Login: req.Login, Password: req.Password}); verr != nil {
+			return statusError(verr)
+		}
+		model, verr := s.sessions.VerifyPassword(req.Login, req.Password)
+		if verr != nil {
+			return statusError(verr)
+		}
+		u, lerr := s.loadUser(model.ID)
+		if lerr != nil {
+			log.Error().Err(lerr).Msg("Failed to get user")
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user")
+		}
+		if len(u.credentials) == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "no passkey registered")
+		}
+		userID, ceremony = model.ID, ceremonySecondFactor
+		assertion, session, err = s.webauthn.BeginLogin(u)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to begin WebAuthn login")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to begin login")
+	}
+
+	challengeID, err := s.saveChallenge(userID, ceremony, session)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to save WebAuthn challenge")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to begin login")
+	}
+
+	return c.JSON(http.StatusOK, beginResponse{ChallengeID: challengeID, PublicKey: assertion.Response})
+}
+
+// FinishLogin verifies the assertion and opens a session, answering like the
+// Login RPC, or approves the device login of the user code
+func (s *Service) FinishLogin(c echo.Context) error {
+	var req finishLoginRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	challenge, session, err := s.takeChallenge(req.ChallengeID, ceremonyLogin, ceremonySecondFactor)
+	if err != nil {
+		return challengeError(err)
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(req.Credential)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid credential")
+	}
+
+	var (
+		u          *user
+		credential *webauthn.Credential
+	)
+	if challenge.Ceremony == ceremonyLogin {
+		var found webauthn.User
+		found, credential, err = s.webauthn.ValidatePasskeyLogin(func(rawID, userHandle []byte) (webauthn.User, error) {
+			return s.loadUser(string(userHandle))
+		}, *session, parsed)
+		if err == nil {
+			u = found.(*user)
+		}
+	} else {
+		if u, err = s.loadUser(challenge.UserID); err != nil {
+			log.Error().Err(err).Msg("Failed to get user")
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user")
+		}
+		credential, err = s.webauthn.ValidateLogin(u, *session, parsed)
+	}
+	if err != nil {
+		log.Debug().Str("details", protocolDetails(err)).Msg("WebAuthn login rejected")
+		return echo.NewHTTPError(http.StatusUnauthorized, "passkey verification failed")
+	}
+	if credential.Authenticator.CloneWarning {
+		log.Warn().Str("user_id", u.model.ID).Msg("Signature counter of a passkey went backwards, it may be cloned")
+		return echo.NewHTTPError(http.StatusUnauthorized, "passkey verification failed")
+	}
+
+	if err := s.updateCredential(credential); err != nil {
+		log.Error().Err(err).Msg("Failed to update credential")
+	}
+
+	if req.UserCode != "" {
+		if err := s.sessions.ApproveDevice(req.UserCode, u.model.ID); err != nil {
+			return statusError(err)
+		}
+		return c.JSON(http.StatusOK, map[string]bool{"success": true})
+	}
+
+	tokens, err := s.sessions.OpenSession(u.model.ID)
+	if err != nil {
+		return statusError(err)
+	}
+	body, err := protojson.Marshal(tokens)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode tokens")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to open session")
+	}
+
+	return c.JSONBlob(http.StatusOK, body)
+}
+
+// credentialToResponse converts a stored credential to its API representation
+func credentialToResponse(record *models.WebAuthnCredential) credentialResponse {
+	return credentialResponse{
+		ID:         record.ID,
+		Name:       record.Name,
+		CreatedAt:  record.CreatedAt,
+		LastUsedAt: record.LastUsedAt,
+	}
+}
+
+// challengeError converts a challenge lookup error to an HTTP error
+func challengeError(err error) error {
+	if errors.Is(err, errChallengeNotFound) {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid or expired challenge")
+	}
+	log.Error().Err(err).Msg("Failed to get WebAuthn challenge")
+
+	return echo.NewHTTPError(http.StatusInternalServerError, "failed to get challenge")
+}
+
+// statusError converts a gRPC status error to the matching HTTP error
+func statusError(err error) error {
+	st := status.Convert(err)
+	return echo.NewHTTPError(runtime.HTTPStatusFromCode(st.Code()), st.Message())
+}
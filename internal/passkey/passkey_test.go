@@ -0,0 +1,431 @@
+package passkey
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/database"
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	testOrigin   = "http://localhost:8080"
+	testRPID     = "localhost"
+	testPassword = "correct horse battery"
+)
+
+// Authenticator data flags (WebAuthn §6.1)
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+)
+
+func TestRegisterAndLoginWithoutPassword(t *testing.T) {
+	h := newHarness(t)
+	authenticator := h.register()
+
+	var records []models.WebAuthnCredential
+	if err := h.db.Find(&records, "user_id = ?", h.user.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].CredentialID != encodeCredentialID(authenticator.id) {
+		t.Fatalf("expected the registered credential, got %+v", records)
+	}
+
+	begin := h.begin(map[string]string{})
+	if len(begin.PublicKey.AllowCredentials) != 0 {
+		t.Errorf("discoverable login should not list credentials, got %v", begin.PublicKey.AllowCredentials)
+	}
+	rec := h.post("/api/v1/webauthn/login/finish", "", map[string]any{
+		"challengeId": begin.ChallengeID,
+		"credential":  authenticator.assert(begin.PublicKey.Challenge),
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("finish login: %d %s", rec.Code, rec.Body)
+	}
+	h.assertSession(rec)
+
+	if err := h.db.First(&records[0], "id = ?", records[0].ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if records[0].LastUsedAt == nil {
+		t.Error("expected the credential use to be recorded")
+	}
+}
+
+func TestPasskeyAsSecondFactor(t *testing.T) {
+	h := newHarness(t)
+	authenticator := h.register()
+
+	// Without TOTP, password logins are sent to the WebAuthn endpoints
+	if _, err := h.auth.VerifyLogin(h.user.Username, testPassword, ""); !errors.Is(err, auth.ErrPasskeyRequired) {
+		t.Fatalf("expected ErrPasskeyRequired, got %v", err)
+	}
+
+	rec := h.post("/api/v1/webauthn/login/begin", "", map[string]string{"login": h.user.Username, "password": "wrong password"})
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("wrong password: expected 401, got %d %s", rec.Code, rec.Body)
+	}
+
+	begin := h.begin(map[string]string{"login": h.user.Username, "password": testPassword})
+	allowed := begin.PublicKey.AllowCredentials
+	if len(allowed) != 1 || allowed[0].ID != base64.RawURLEncoding.EncodeToString(authenticator.id) {
+		t.Fatalf("expected the credential of the user to be allowed, got %v", allowed)
+	}
+
+	credential := authenticator.assert(begin.PublicKey.Challenge)
+	rec = h.post("/api/v1/webauthn/login/finish", "", map[string]any{"challengeId": begin.ChallengeID, "credential": credential})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("finish login: %d %s", rec.Code, rec.Body)
+	}
+	h.assertSession(rec)
+
+	// Each challenge is answered once
+	rec = h.post("/api/v1/webauthn/login/finish", "", map[string]any{"challengeId": begin.ChallengeID, "credential": credential})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("replayed challenge: expected 400, got %d %s", rec.Code, rec.Body)
+	}
+}
+
+func TestPasskeyApprovesDeviceLogin(t *testing.T) {
+	h := newHarness(t)
+	authenticator := h.register()
+
+	device, err := h.auth.StartDeviceLogin(context.Background(), &aliasme.StartDeviceLoginRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	begin := h.begin(map[string]string{})
+	rec := h.post("/api/v1/webauthn/login/finish", "", map[string]any{
+		"challengeId": begin.ChallengeID,
+		"credential":  authenticator.assert(begin.PublicKey.Challenge),
+		"userCode":    device.UserCode,
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("finish login: %d %s", rec.Code, rec.Body)
+	}
+	if bytes.Contains(rec.Body.Bytes(), []byte("accessToken")) {
+		t.Error("approving a device login should not open a session for the browser")
+	}
+
+	tokens, err := h.auth.PollDeviceLogin(context.Background(), &aliasme.PollDeviceLoginRequest{DeviceCode: device.DeviceCode})
+	if err != nil {
+		t.Fatalf("poll device login: %v", err)
+	}
+	identity, err := h.auth.AuthenticateSession(tokens.AccessToken)
+	if err != nil || identity.UserID != h.user.ID {
+		t.Fatalf("expected a session of the user, got %+v (%v)", identity, err)
+	}
+}
+
+func TestPasskeyCounterGoingBackwardsIsRejected(t *testing.T) {
+	h := newHarness(t)
+	authenticator := h.register()
+
+	authenticator.counter = 10
+	begin := h.begin(map[string]string{})
+	rec := h.post("/api/v1/webauthn/login/finish", "", map[string]any{
+		"challengeId": begin.ChallengeID,
+		"credential":  authenticator.assert(begin.PublicKey.Challenge),
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("finish login: %d %s", rec.Code, rec.Body)
+	}
+
+	// A clone of the authenticator signs with an older counter
+	authenticator.counter = 5
+	begin = h.begin(map[string]string{})
+	rec = h.post("/api/v1/webauthn/login/finish", "", map[string]any{
+		"challengeId": begin.ChallengeID,
+		"credential":  authenticator.assert(begin.PublicKey.Challenge),
+	})
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("cloned authenticator: expected 401, got %d %s", rec.Code, rec.Body)
+	}
+}
+
+// harness serves the WebAuthn endpoints for a user, with the real auth
+// service and a temporary database
+type harness struct {
+	t    *testing.T
+	db   *gorm.DB
+	auth *auth.Service
+	echo *echo.Echo
+	user *models.User
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	db, err := database.New(&database.Config{Path: filepath.Join(t.TempDir(), "aliasme.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	authService := auth.New(db, auth.Config{
+		Secret:             []byte("test secret"),
+		AccessTokenTTL:     time.Minute,
+		RefreshTokenTTL:    time.Hour,
+		DeviceCodeTTL:      time.Minute,
+		DevicePollInterval: time.Second,
+	}, nil)
+	service, err := New(db, Config{BaseURL: testOrigin, RPDisplayName: "AliasMe", ChallengeTTL: time.Minute}, authService)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := echo.New()
+	e.POST("/api/v1/webauthn/register/begin", service.BeginRegistration, service.RequireSession)
+	e.POST("/api/v1/webauthn/register/finish", service.FinishRegistration, service.RequireSession)
+	e.POST("/api/v1/webauthn/login/begin", service.BeginLogin)
+	e.POST("/api/v1/webauthn/login/finish", service.FinishLogin)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{ID: "user1", Username: "alice", Email: "alice@example.org", Password: string(hash)}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return &harness{t: t, db: db, auth: authService, echo: e, user: user}
+}
+
+// testBeginResponse is the answer of the begin endpoints, with the fields the
+// software authenticator needs
+type testBeginResponse struct {
+	ChallengeID string `json:"challengeId"`
+	PublicKey   struct {
+		Challenge string `json:"challenge"`
+		User      struct {
+			ID string `json:"id"`
+		} `json:"user"`
+		AllowCredentials []struct {
+			ID string `json:"id"`
+		} `json:"allowCredentials"`
+	} `json:"publicKey"`
+}
+
+// register registers a new software authenticator for the user
+func (h *harness) register() *softAuthenticator {
+	h.t.Helper()
+
+	session, err := h.auth.OpenSession(h.user.ID)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+
+	rec := h.post("/api/v1/webauthn/register/begin", session.AccessToken, map[string]string{})
+	if rec.Code != http.StatusOK {
+		h.t.Fatalf("begin registration: %d %s", rec.Code, rec.Body)
+	}
+	var begin testBeginResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &begin); err != nil {
+		h.t.Fatal(err)
+	}
+
+	authenticator := newSoftAuthenticator(h.t, begin.PublicKey.User.ID)
+	rec = h.post("/api/v1/webauthn/register/finish", session.AccessToken, map[string]any{
+		"challengeId": begin.ChallengeID,
+		"name":        "Test key",
+		"credential":  authenticator.create(begin.PublicKey.Challenge),
+	})
+	if rec.Code != http.StatusCreated {
+		h.t.Fatalf("finish registration: %d %s", rec.Code, rec.Body)
+	}
+
+	return authenticator
+}
+
+// begin starts a login
+func (h *harness) begin(body any) *testBeginResponse {
+	h.t.Helper()
+
+	rec := h.post("/api/v1/webauthn/login/begin", "", body)
+	if rec.Code != http.StatusOK {
+		h.t.Fatalf("begin login: %d %s", rec.Code, rec.Body)
+	}
+	var begin testBeginResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &begin); err != nil {
+		h.t.Fatal(err)
+	}
+
+	return &begin
+}
+
+// post sends a JSON request, authenticated when a token is given
+func (h *harness) post(path, token string, body any) *httptest.ResponseRecorder {
+	h.t.Helper()
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.echo.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// assertSession checks the response holds an access token of the user
+func (h *harness) assertSession(rec *httptest.ResponseRecorder) {
+	h.t.Helper()
+
+	var tokens struct {
+		AccessToken string `json:"accessToken"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &tokens); err != nil {
+		h.t.Fatal(err)
+	}
+	identity, err := h.auth.AuthenticateSession(tokens.AccessToken)
+	if err != nil || identity.UserID != h.user.ID {
+		h.t.Fatalf("expected a session of the user, got %+v (%v)", identity, err)
+	}
+}
+
+// softAuthenticator is a software authenticator signing with a P-256 key and
+// attesting with the none format
+type softAuthenticator struct {
+	t          *testing.T
+	key        *ecdsa.PrivateKey
+	id         []byte
+	userHandle []byte
+	counter    uint32
+}
+
+func newSoftAuthenticator(t *testing.T, userHandle string) *softAuthenticator {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		t.Fatal(err)
+	}
+	handle, err := base64.RawURLEncoding.DecodeString(userHandle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &softAuthenticator{t: t, key: key, id: id, userHandle: handle}
+}
+
+// create answers a registration challenge
+func (a *softAuthenticator) create(challenge string) map[string]any {
+	a.t.Helper()
+
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  int64(webauthncose.P256),
+		XCoord: a.key.X.FillBytes(make([]byte, 32)),
+		YCoord: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	// Attested credential data: AAGUID, credential ID length, ID and key
+	attested := make([]byte, 16, 18+len(a.id)+len(publicKey))
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.id)))
+	attested = append(attested, a.id...)
+	attested = append(attested, publicKey...)
+
+	attestation, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": append(a.authenticatorData(flagUserPresent|flagUserVerified|flagAttested), attested...),
+	})
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	return map[string]any{
+		"id":    base64.RawURLEncoding.EncodeToString(a.id),
+		"rawId": base64.RawURLEncoding.EncodeToString(a.id),
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(a.clientData("webauthn.create", challenge)),
+			"attestationObject": base64.RawURLEncoding.EncodeToString(attestation),
+		},
+	}
+}
+
+// assert answers a login challenge with user verification
+func (a *softAuthenticator) assert(challenge string) map[string]any {
+	a.t.Helper()
+
+	authData := a.authenticatorData(flagUserPresent | flagUserVerified)
+	clientData := a.clientData("webauthn.get", challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	return map[string]any{
+		"id":    base64.RawURLEncoding.EncodeToString(a.id),
+		"rawId": base64.RawURLEncoding.EncodeToString(a.id),
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+			"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+			"signature":         base64.RawURLEncoding.EncodeToString(signature),
+			"userHandle":        base64.RawURLEncoding.EncodeToString(a.userHandle),
+		},
+	}
+}
+
+// authenticatorData returns the RP ID hash, flags and signature counter
+func (a *softAuthenticator) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	data := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(data, a.counter)
+}
+
+// clientData returns the client data JSON of a ceremony
+func (a *softAuthenticator) clientData(ceremony, challenge string) []byte {
+	data, err := json.Marshal(map[string]any{
+		"type":        ceremony,
+		"challenge":   challenge,
+		"origin":      testOrigin,
+		"crossOrigin": false,
+	})
+	if err != nil {
+		a.t.Fatal(err)
+	}
+
+	return data
+}
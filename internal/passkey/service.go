@@ -0,0 +1,234 @@
+// Copyright 2024 AliasMe
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passkey
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/xid"
+	"gorm.io/gorm"
+)
+
+// WebAuthn ceremonies
+const (
+	ceremonyRegistration = "registration"
+	ceremonyLogin        = "login"
+	ceremonySecondFactor = "second_factor"
+)
+
+// errChallengeNotFound is returned for unknown, used or expired challenges
+var errChallengeNotFound = errors.New("challenge not found")
+
+// Config holds the WebAuthn relying party configuration
+type Config struct {
+	// BaseURL is the public URL of the server, from which the relying party ID
+	// and origin default
+	BaseURL string
+	// RPID is the relying party ID, the domain credentials are scoped to
+	RPID string
+	// RPDisplayName is the name authenticators show to the user
+	RPDisplayName string
+	// Origins are the origins allowed to run ceremonies
+	Origins []string
+	// ChallengeTTL is how long a ceremony may take
+	ChallengeTTL time.Duration
+}
+
+// Sessions verifies passwords and access tokens, opens login sessions and
+// approves device logins
+type Sessions interface {
+	AuthenticateSession(token string) (*auth.Identity, error)
+	VerifyPassword(login, password string) (*models.User, error)
+	OpenSession(userID string) (*aliasme.TokenResponse, error)
+	ApproveDevice(userCode, userID string) error
+}
+
+// Service handles the WebAuthn registration and login ceremonies
+type Service struct {
+	db       *gorm.DB
+	config   Config
+	webauthn *webauthn.WebAuthn
+	sessions Sessions
+}
+
+// New creates a new passkey service
+func New(db *gorm.DB, cfg Config, sessions Sessions) (*Service, error) {
+	base, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if cfg.RPID == "" {
+		cfg.RPID = base.Hostname()
+	}
+	if len(cfg.Origins) == 0 {
+		cfg.Origins = []string{base.Scheme + "://" + base.Host}
+	}
+
+	timeout := webauthn.TimeoutConfig{Enforce: true, Timeout: cfg.ChallengeTTL, TimeoutUVD: cfg.ChallengeTTL}
+	w, err := webauthn.New(&webauthn.Config{
+		RPID:          cfg.RPID,
+		RPDisplayName: cfg.RPDisplayName,
+		RPOrigins:     cfg.Origins,
+		Timeouts:      webauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{db: db, config: cfg, webauthn: w, sessions: sessions}, nil
+}
+
+// user adapts a user and their credentials to the WebAuthn library
+type user struct {
+	model       *models.User
+	credentials []webauthn.Credential
+}
+
+// WebAuthnID returns the user handle, the user ID
+func (u *user) WebAuthnID() []byte {
+	return []byte(u.model.ID)
+}
+
+// WebAuthnName returns the username
+func (u *user) WebAuthnName() string {
+	return u.model.Username
+}
+
+// WebAuthnDisplayName returns the username
+func (u *user) WebAuthnDisplayName() string {
+	return u.model.Username
+}
+
+// WebAuthnCredentials returns the registered credentials
+func (u *user) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+// loadUser returns the user with their registered credentials
+func (s *Service) loadUser(userID string) (*user, error) {
+	var model models.User
+	if err := s.db.First(&model, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+
+	var records []models.WebAuthnCredential
+	if err := s.db.Where("user_id = ?", userID).Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	u := &user{model: &model, credentials: make([]webauthn.Credential, len(records))}
+	for i, record := range records {
+		if err := json.Unmarshal([]byte(record.Credential), &u.credentials[i]); err != nil {
+			return nil, fmt.Errorf("invalid credential %s: %w", record.ID, err)
+		}
+	}
+
+	return u, nil
+}
+
+// saveChallenge stores the state of a ceremony until it finishes, and drops
+// the expired ones
+func (s *Service) saveChallenge(userID, ceremony string, session *webauthn.SessionData) (string, error) {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	if err := s.db.Where("expires_at <= ?", now).Delete(&models.WebAuthnChallenge{}).Error; err != nil {
+		return "", err
+	}
+
+	challenge := &models.WebAuthnChallenge{
+		ID:        xid.New().String(),
+		UserID:    userID,
+		Ceremony:  ceremony,
+		Data:      string(data),
+		ExpiresAt: now.Add(s.config.ChallengeTTL),
+		CreatedAt: now,
+	}
+	if err := s.db.Create(challenge).Error; err != nil {
+		return "", err
+	}
+
+	return challenge.ID, nil
+}
+
+// takeChallenge returns and deletes the state of a pending ceremony, so each
+// challenge is answered at most once
+func (s *Service) takeChallenge(id string, ceremonies ...string) (*models.WebAuthnChallenge, *webauthn.SessionData, error) {
+	var challenge models.WebAuthnChallenge
+	err := s.db.Where("id = ? AND ceremony IN ? AND expires_at > ?", id, ceremonies, time.Now()).First(&challenge).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errChallengeNotFound
+		}
+		return nil, nil, err
+	}
+
+	// Concurrent answers to the same challenge: only one takes it
+	result := s.db.Delete(&models.WebAuthnChallenge{}, "id = ?", challenge.ID)
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil, errChallengeNotFound
+	}
+
+	var session webauthn.SessionData
+	if err := json.Unmarshal([]byte(challenge.Data), &session); err != nil {
+		return nil, nil, err
+	}
+
+	return &challenge, &session, nil
+}
+
+// updateCredential records the signature counter and flags of a credential
+// after a login
+func (s *Service) updateCredential(credential *webauthn.Credential) error {
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Model(&models.WebAuthnCredential{}).
+		Where("credential_id = ?", encodeCredentialID(credential.ID)).
+		Updates(map[string]any{"credential": string(data), "last_used_at": time.Now()}).Error
+}
+
+// encodeCredentialID encodes a credential ID as stored in the database
+func encodeCredentialID(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+// protocolDetails returns the details of a WebAuthn verification error
+func protocolDetails(err error) string {
+	var protoErr *protocol.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Details + ": " + protoErr.DevInfo
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,72 @@
+package plan
+
+import (
+	"slices"
+)
+
+// Alias generator strategies that plans can allow
+const (
+	// StrategyCustom creates an alias from a caller supplied prefix
+	StrategyCustom = "custom"
+	// StrategyWebsite creates an alias with a prefix derived from a hostname
+	StrategyWebsite = "website"
+)
+
+// Plan holds the limits applied to the users assigned to it. Zero limits and
+// empty lists mean unlimited.
+type Plan struct {
+	Name              string   `mapstructure:"-"`
+	MaxAliases        int      `mapstructure:"max_aliases"`
+	MaxEmails         int      `mapstructure:"max_emails"`
+	AllowedDomains    []string `mapstructure:"allowed_domains"`
+	AllowedStrategies []string `mapstructure:"allowed_strategies"`
+}
+
+// AllowsDomain reports whether aliases may be created on the domain
+func (p Plan) AllowsDomain(domain string) bool {
+	return len(p.AllowedDomains) == 0 || slices.Contains(p.AllowedDomains, domain)
+}
+
+// AllowsStrategy reports whether aliases may be created with the strategy
+func (p Plan) AllowsStrategy(strategy string) bool {
+	return len(p.AllowedStrategies) == 0 || slices.Contains(p.AllowedStrategies, strategy)
+}
+
+// Config holds the plan catalog configuration
+type Config struct {
+	// Default is the plan of users without an assigned plan
+	Default string          `mapstructure:"default"`
+	Tiers   map[string]Plan `mapstructure:"tiers"`
+}
+
+// Catalog resolves plan names to their limits
+type Catalog struct {
+	config Config
+}
+
+// NewCatalog creates a plan catalog from the configuration
+func NewCatalog(cfg Config) *Catalog {
+	return &Catalog{config: cfg}
+}
+
+// Exists reports whether a plan with this name is configured
+func (c *Catalog) Exists(name string) bool {
+	_, ok := c.config.Tiers[name]
+	return ok
+}
+
+// Get returns the named plan, falling back to the default plan for empty or
+// unknown names. Without any configured plan, an unlimited plan is returned.
+func (c *Catalog) Get(name string) Plan {
+	if name == "" || !c.Exists(name) {
+		name = c.config.Default
+	}
+
+	p, ok := c.config.Tiers[name]
+	if !ok {
+		return Plan{Name: name}
+	}
+	p.Name = name
+
+	return p
+}
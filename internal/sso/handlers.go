@@ -0,0 +1,189 @@
+package sso
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golgoth31/aliasme/internal/web"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// stateCookie carries the state, nonce and PKCE verifier of a pending login,
+// and the user code of the device login it approves
+const stateCookie = "aliasme_oidc"
+
+// stateTTL is how long the user has to log in with the identity provider
+const stateTTL = 10 * time.Minute
+
+// LoginHandler redirects to the identity provider. With a user_code, the
+// login leads to the approval of that device login instead of opening a
+// session.
+func (s *Service) LoginHandler(c echo.Context) error {
+	provider, err := s.discover(c.Request().Context())
+	if err != nil {
+		log.Error().Err(err).Str("issuer", s.config.Issuer).Msg("Failed to discover OIDC provider")
+		return echo.NewHTTPError(http.StatusBadGateway, "identity provider unavailable")
+	}
+
+	state, err := randomString()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate OIDC state")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to start login")
+	}
+	nonce, err := randomString()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to generate OIDC nonce")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to start login")
+	}
+	verifier := oauth2.GenerateVerifier()
+	userCode := strings.Map(func(r rune) rune {
+		if r == '-' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') {
+			return r
+		}
+		return -1
+	}, c.QueryParam("user_code"))
+
+	c.SetCookie(&http.Cookie{
+		Name:     stateCookie,
+		Value:    strings.Join([]string{state, nonce, verifier, userCode}, "."),
+		Path:     "/",
+		MaxAge:   int(stateTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(s.config.RedirectURL, "https://"),
+		// Lax, so the cookie comes back with the provider redirect
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	url := s.oauth2Config(provider).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier))
+	return c.Redirect(http.StatusFound, url)
+}
+
+// CallbackHandler completes the login when the identity provider redirects
+// back, and answers like the Login RPC or asks the user to approve the device
+// login
+func (s *Service) CallbackHandler(c echo.Context) error {
+	cookie, err := c.Cookie(stateCookie)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "login expired, try again")
+	}
+	c.SetCookie(&http.Cookie{Name: stateCookie, Path: "/", MaxAge: -1, HttpOnly: true})
+
+	parts := strings.Split(cookie.Value, ".")
+	if len(parts) != 4 || subtle.ConstantTimeCompare([]byte(parts[0]), []byte(c.QueryParam("state"))) != 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid login state, try again")
+	}
+	nonce, verifier, userCode := parts[1], parts[2], parts[3]
+
+	if reason := c.QueryParam("error"); reason != "" {
+		log.Info().Str("error", reason).Str("description", c.QueryParam("error_description")).Msg("OIDC login refused by the identity provider")
+		return echo.NewHTTPError(http.StatusUnauthorized, "login refused by the identity provider")
+	}
+
+	ctx := c.Request().Context()
+	provider, err := s.discover(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("issuer", s.config.Issuer).Msg("Failed to discover OIDC provider")
+		return echo.NewHTTPError(http.StatusBadGateway, "identity provider unavailable")
+	}
+
+	token, err := s.oauth2Config(provider).Exchange(ctx, c.QueryParam("code"), oauth2.VerifierOption(verifier))
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to exchange OIDC authorization code")
+		return echo.NewHTTPError(http.StatusUnauthorized, "login failed")
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		log.Warn().Msg("OIDC token response has no ID token")
+		return echo.NewHTTPError(http.StatusUnauthorized, "login failed")
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: s.config.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		log.Warn().Err(err).Msg("Invalid OIDC ID token")
+		return echo.NewHTTPError(http.StatusUnauthorized, "login failed")
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+		log.Warn().Msg("OIDC ID token nonce mismatch")
+		return echo.NewHTTPError(http.StatusUnauthorized, "login failed")
+	}
+
+	var (
+		idClaims claims
+		all      map[string]any
+	)
+	if err := idToken.Claims(&idClaims); err != nil {
+		log.Warn().Err(err).Msg("Invalid OIDC ID token claims")
+		return echo.NewHTTPError(http.StatusUnauthorized, "login failed")
+	}
+	if err := idToken.Claims(&all); err != nil {
+		log.Warn().Err(err).Msg("Invalid OIDC ID token claims")
+		return echo.NewHTTPError(http.StatusUnauthorized, "login failed")
+	}
+	groups, err := groupsClaim(all, s.config.GroupsClaim)
+	if err != nil {
+		log.Warn().Err(err).Msg("Invalid OIDC groups claim")
+		return echo.NewHTTPError(http.StatusUnauthorized, "login failed")
+	}
+
+	user, err := s.provision(idToken.Issuer, idToken.Subject, &idClaims, groups)
+	if err != nil {
+		switch {
+		case errors.Is(err, errNotAllowed), errors.Is(err, errAccountDeleted):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case errors.Is(err, errMissingClaims):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, errUsernameTaken), errors.Is(err, errEmailTaken):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		log.Error().Err(err).Msg("Failed to provision user")
+		return echo.NewHTTPError(http.StatusInternalServerError, "login failed")
+	}
+
+	// The user confirms the approval, as they may not have started the
+	// device login themselves
+	if userCode != "" {
+		token, err := s.sessions.DeviceApprovalToken(userCode, user.ID)
+		switch status.Code(err) {
+		case codes.OK:
+			return web.SSODeviceForm(c, userCode, user.Username, token)
+		case codes.NotFound:
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid or expired device code, start the login on the device again")
+		default:
+			log.Error().Err(err).Msg("Failed to approve device login")
+			return echo.NewHTTPError(http.StatusInternalServerError, "login failed")
+		}
+	}
+
+	tokens, err := s.sessions.OpenSession(user.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to open session")
+		return echo.NewHTTPError(http.StatusInternalServerError, "login failed")
+	}
+	body, err := protojson.Marshal(tokens)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode tokens")
+		return echo.NewHTTPError(http.StatusInternalServerError, "login failed")
+	}
+
+	return c.JSONBlob(http.StatusOK, body)
+}
+
+// randomString returns a random URL-safe string
+func randomString() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2024 AliasMe
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sso
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/models"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+// Provisioning errors, shown to the user
+var (
+	errNotAllowed     = errors.New("your account is not allowed to use AliasMe")
+	errMissingClaims  = errors.New("the identity provider did not share your email address")
+	errUsernameTaken  = errors.New("your username is already used by another account")
+	errEmailTaken     = errors.New("your email address is already used by another account")
+	errAccountDeleted = errors.New("your account was deleted")
+)
+
+// Config holds the OpenID Connect configuration
+type Config struct {
+	// Issuer is the URL of the identity provider
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the callback URL registered with the identity provider
+	RedirectURL string
+	// Scopes are requested in addition to openid
+	Scopes []string
+	// GroupsClaim is the ID token claim listing the groups of the user
+	GroupsClaim string
+	// RoleGroups maps roles to the groups granting them. When groups are set
+	// for the user role, members of no listed group are refused.
+	RoleGroups map[string][]string
+}
+
+// Sessions opens login sessions and issues the tokens confirming device login
+// approvals
+type Sessions interface {
+	OpenSession(userID string) (*aliasme.TokenResponse, error)
+	DeviceApprovalToken(userCode, userID string) (string, error)
+}
+
+// Service logs users in through an OpenID Connect provider, creating their
+// account on first login
+type Service struct {
+	db       *gorm.DB
+	config   Config
+	sessions Sessions
+
+	// The provider is discovered on first use, so the server starts while
+	// the identity provider is down
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// New creates a new single sign-on service
+func New(db *gorm.DB, cfg Config, sessions Sessions) *Service {
+	return &Service{db: db, config: cfg, sessions: sessions}
+}
+
+// claims are the ID token claims accounts are provisioned from
+type claims struct {
+	Email             string `json:"email"`
+	EmailVerified     *bool  `json:"email_verified"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// discover returns the provider, fetching its discovery document if needed
+func (s *Service) discover(ctx context.Context) (*oidc.Provider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.provider == nil {
+		provider, err := oidc.NewProvider(ctx, s.config.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		s.provider = provider
+	}
+
+	return s.provider, nil
+}
+
+// oauth2Config returns the OAuth 2.0 client configuration of the provider
+func (s *Service) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.config.ClientID,
+		ClientSecret: s.config.ClientSecret,
+		RedirectURL:  s.config.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       append([]string{oidc.ScopeOpenID}, s.config.Scopes...),
+	}
+}
+
+// resolveRole returns the role the groups grant, and false when they do not
+// allow using AliasMe
+func (s *Service) resolveRole(groups []string) (string, bool) {
+	member := func(role string) bool {
+		for _, allowed := range s.config.RoleGroups[role] {
+			for _, group := range groups {
+				if group == allowed {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	switch {
+	case member(models.RoleAdmin):
+		return models.RoleAdmin, true
+	case len(s.config.RoleGroups[models.RoleUser]) == 0 || member(models.RoleUser):
+		return models.RoleUser, true
+	default:
+		return "", false
+	}
+}
+
+// provision returns the user of the single sign-on account, linking it to the
+// user with the same verified email or creating a user on first login. The
+// role follows the groups when role groups are configured. Linked users lose
+// their password and other credentials, as whoever registered the account may
+// not own the email.
+func (s *Service) provision(issuer, subject string, c *claims, groups []string) (*models.User, error) {
+	role, ok := s.resolveRole(groups)
+	if !ok {
+		return nil, errNotAllowed
+	}
+	syncRole := len(s.config.RoleGroups) > 0
+
+	var user models.User
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().First(&user, "oidc_issuer = ? AND oidc_subject = ?", issuer, subject).Error
+		if err == nil {
+			if user.DeletedAt.Valid {
+				return errAccountDeleted
+			}
+			if syncRole && user.Role != role {
+				return tx.Model(&user).Updates(map[string]any{"role": role, "updated_at": time.Now()}).Error
+			}
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		address := strings.TrimSpace(c.Email)
+		if address == "" || (c.EmailVerified != nil && !*c.EmailVerified) {
+			return errMissingClaims
+		}
+
+		// Existing accounts are only linked when the provider vouches for
+		// the email
+		err = tx.First(&user, "email = ?", address).Error
+		if err == nil {
+			if c.EmailVerified == nil || !*c.EmailVerified {
+				return errEmailTaken
+			}
+			updates := map[string]any{"oidc_issuer": issuer, "oidc_subject": subject, "password": "", "updated_at": time.Now()}
+			if syncRole {
+				updates["role"] = role
+			}
+			if err := tx.Model(&user).Updates(updates).Error; err != nil {
+				return err
+			}
+			if err := auth.RevokeCredentials(tx, user.ID); err != nil {
+				return err
+			}
+			if err := auth.ClearTOTP(tx, user.ID); err != nil {
+				return err
+			}
+			if err := tx.Where("user_id = ?", user.ID).Delete(&models.WebAuthnCredential{}).Error; err != nil {
+				return err
+			}
+			log.Info().Str("user_id", user.ID).Msg("Linked user to single sign-on account")
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		username := strings.TrimSpace(c.PreferredUsername)
+		if username == "" {
+			username, _, _ = strings.Cut(address, "@")
+		}
+		// Deleted users keep their username and email until purged
+		for _, conflict := range []struct {
+			column, value string
+			err           error
+		}{
+			{"username", username, errUsernameTaken},
+			{"email", address, errEmailTaken},
+		} {
+			var taken int64
+			if err := tx.Unscoped().Model(&models.User{}).Where(conflict.column+" = ?", conflict.value).Count(&taken).Error; err != nil {
+				return err
+			}
+			if taken > 0 {
+				return conflict.err
+			}
+		}
+
+		now := time.Now()
+		user = models.User{
+			ID:          xid.New().String(),
+			Username:    username,
+			Email:       address,
+			Role:        role,
+			OIDCIssuer:  issuer,
+			OIDCSubject: subject,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		log.Info().Str("user_id", user.ID).Str("username", username).Msg("Created user from single sign-on account")
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// groupsClaim returns the groups listed in the configured claim
+func groupsClaim(all map[string]any, name string) ([]string, error) {
+	value, ok := all[name]
+	if !ok {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		groups := make([]string, 0, len(v))
+		for _, item := range v {
+			group, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("claim %s holds a non-string group", name)
+			}
+			groups = append(groups, group)
+		}
+		return groups, nil
+	default:
+		return nil, fmt.Errorf("claim %s is not a list of groups", name)
+	}
+}
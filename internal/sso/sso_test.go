@@ -0,0 +1,502 @@
+package sso
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"html"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/database"
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/web"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	testClientID = "aliasme"
+	testPassword = "correct horse battery"
+)
+
+func TestLoginCreatesUser(t *testing.T) {
+	h := newHarness(t, nil)
+
+	rec := h.login(map[string]any{
+		"sub":                "alice-sub",
+		"email":              "alice@example.org",
+		"email_verified":     true,
+		"preferred_username": "alice",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("callback: %d %s", rec.Code, rec.Body)
+	}
+	user := h.assertSession(rec)
+
+	if user.Username != "alice" || user.Email != "alice@example.org" || user.Role != models.RoleUser {
+		t.Errorf("unexpected user %+v", user)
+	}
+	if user.OIDCIssuer != h.issuer.server.URL || user.OIDCSubject != "alice-sub" || user.Password != "" {
+		t.Errorf("expected a single sign-on user without password, got %+v", user)
+	}
+
+	// The next login finds the same user
+	rec = h.login(map[string]any{"sub": "alice-sub", "email": "alice@example.org", "email_verified": true})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("second callback: %d %s", rec.Code, rec.Body)
+	}
+	if again := h.assertSession(rec); again.ID != user.ID {
+		t.Errorf("expected user %s, got %s", user.ID, again.ID)
+	}
+}
+
+func TestLoginLinksUserWithVerifiedEmail(t *testing.T) {
+	h := newHarness(t, nil)
+	local := h.createLocalUser("bob", "bob@example.org")
+	session, err := h.auth.OpenSession(local.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	passkey := &models.WebAuthnCredential{ID: "passkey1", UserID: local.ID, Name: "Key", CredentialID: "cred1", Credential: "{}"}
+	if err := h.db.Create(passkey).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	rec := h.login(map[string]any{"sub": "bob-sub", "email": "bob@example.org", "email_verified": true})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("callback: %d %s", rec.Code, rec.Body)
+	}
+	user := h.assertSession(rec)
+	if user.ID != local.ID || user.OIDCSubject != "bob-sub" {
+		t.Fatalf("expected the local user to be linked, got %+v", user)
+	}
+
+	// Whoever registered the account loses access to it
+	if user.Password != "" {
+		t.Error("expected the password to be removed")
+	}
+	if _, err := h.auth.VerifyPassword("bob", testPassword); err == nil {
+		t.Error("expected password logins to be refused")
+	}
+	if _, err := h.auth.AuthenticateSession(session.AccessToken); err == nil {
+		t.Error("expected the previous sessions to be revoked")
+	}
+	var passkeys int64
+	if err := h.db.Model(&models.WebAuthnCredential{}).Where("user_id = ?", local.ID).Count(&passkeys).Error; err != nil {
+		t.Fatal(err)
+	}
+	if passkeys != 0 {
+		t.Error("expected the passkeys to be removed")
+	}
+}
+
+func TestLoginRefusesUnverifiedEmail(t *testing.T) {
+	h := newHarness(t, nil)
+	local := h.createLocalUser("carol", "carol@example.org")
+
+	// Without email_verified, the existing account is not linked
+	rec := h.login(map[string]any{"sub": "carol-sub", "email": "carol@example.org"})
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d %s", rec.Code, rec.Body)
+	}
+
+	rec = h.login(map[string]any{"sub": "carol-sub", "email": "carol@example.org", "email_verified": false})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d %s", rec.Code, rec.Body)
+	}
+
+	var user models.User
+	if err := h.db.First(&user, "id = ?", local.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if user.OIDCSubject != "" || user.Password == "" {
+		t.Errorf("expected the local user to be left alone, got %+v", user)
+	}
+}
+
+func TestLoginMapsGroupsToRoles(t *testing.T) {
+	h := newHarness(t, map[string][]string{
+		models.RoleAdmin: {"aliasme-admins"},
+		models.RoleUser:  {"staff"},
+	})
+
+	rec := h.login(map[string]any{"sub": "dave-sub", "email": "dave@example.org", "groups": []string{"staff", "aliasme-admins"}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("callback: %d %s", rec.Code, rec.Body)
+	}
+	if user := h.assertSession(rec); user.Role != models.RoleAdmin {
+		t.Errorf("expected an admin, got %q", user.Role)
+	}
+
+	// The role follows the groups at every login
+	rec = h.login(map[string]any{"sub": "dave-sub", "email": "dave@example.org", "groups": "staff"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("second callback: %d %s", rec.Code, rec.Body)
+	}
+	if user := h.assertSession(rec); user.Role != models.RoleUser {
+		t.Errorf("expected a user, got %q", user.Role)
+	}
+}
+
+func TestLoginRefusesOtherGroups(t *testing.T) {
+	h := newHarness(t, map[string][]string{models.RoleUser: {"staff"}})
+
+	for _, groups := range []any{[]string{"guests"}, nil} {
+		claims := map[string]any{"sub": "eve-sub", "email": "eve@example.org", "email_verified": true}
+		if groups != nil {
+			claims["groups"] = groups
+		}
+		rec := h.login(claims)
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("groups %v: expected 403, got %d %s", groups, rec.Code, rec.Body)
+		}
+	}
+
+	var users int64
+	if err := h.db.Model(&models.User{}).Count(&users).Error; err != nil {
+		t.Fatal(err)
+	}
+	if users != 0 {
+		t.Errorf("expected no user to be created, got %d", users)
+	}
+}
+
+func TestLoginAsksToApproveDeviceLogin(t *testing.T) {
+	h := newHarness(t, nil)
+	device, err := h.auth.StartDeviceLogin(context.Background(), &aliasme.StartDeviceLoginRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := h.loginAt("/auth/oidc/login?user_code="+url.QueryEscape(device.UserCode), map[string]any{
+		"sub":            "frank-sub",
+		"email":          "frank@example.org",
+		"email_verified": true,
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("callback: %d %s", rec.Code, rec.Body)
+	}
+	match := regexp.MustCompile(`name="approval_token" value="([^"]+)"`).FindStringSubmatch(rec.Body.String())
+	if match == nil {
+		t.Fatalf("expected the approval form, got %s", rec.Body)
+	}
+
+	// Logging in alone does not approve the device
+	poll := &aliasme.PollDeviceLoginRequest{DeviceCode: device.DeviceCode}
+	if _, err := h.auth.PollDeviceLogin(context.Background(), poll); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected the device login to be pending, got %v", err)
+	}
+
+	form := url.Values{"user_code": {device.UserCode}, "approval_token": {"forged"}, "action": {"approve"}}
+	if rec := h.postForm("/device", form); rec.Code != http.StatusBadRequest {
+		t.Fatalf("forged token: expected 400, got %d %s", rec.Code, rec.Body)
+	}
+
+	form.Set("approval_token", html.UnescapeString(match[1]))
+	if rec := h.postForm("/device", form); rec.Code != http.StatusOK {
+		t.Fatalf("approve: %d %s", rec.Code, rec.Body)
+	}
+	tokens, err := h.auth.PollDeviceLogin(context.Background(), poll)
+	if err != nil {
+		t.Fatalf("poll device login: %v", err)
+	}
+	identity, err := h.auth.AuthenticateSession(tokens.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var user models.User
+	if err := h.db.First(&user, "id = ?", identity.UserID).Error; err != nil || user.Email != "frank@example.org" {
+		t.Fatalf("expected a session of the single sign-on user, got %+v (%v)", user, err)
+	}
+}
+
+// harness serves the single sign-on endpoints against a test issuer, with the
+// real auth service and a temporary database
+type harness struct {
+	t      *testing.T
+	db     *gorm.DB
+	auth   *auth.Service
+	echo   *echo.Echo
+	issuer *testIssuer
+}
+
+func newHarness(t *testing.T, roleGroups map[string][]string) *harness {
+	t.Helper()
+
+	db, err := database.New(&database.Config{Path: filepath.Join(t.TempDir(), "aliasme.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	authService := auth.New(db, auth.Config{
+		Secret:          []byte("test secret"),
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+		DeviceCodeTTL:   time.Minute,
+	}, nil)
+
+	issuer := newTestIssuer(t)
+	service := New(db, Config{
+		Issuer:       issuer.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: "client secret",
+		RedirectURL:  "http://localhost:8080/auth/oidc/callback",
+		Scopes:       []string{"email", "profile"},
+		GroupsClaim:  "groups",
+		RoleGroups:   roleGroups,
+	}, authService)
+
+	e := echo.New()
+	e.GET("/auth/oidc/login", service.LoginHandler)
+	e.GET("/auth/oidc/callback", service.CallbackHandler)
+	e.POST("/device", web.DeviceHandler(authService, true))
+
+	return &harness{t: t, db: db, auth: authService, echo: e, issuer: issuer}
+}
+
+// login goes through the authorization code flow, the issuer returning an ID
+// token with the claims
+func (h *harness) login(claims map[string]any) *httptest.ResponseRecorder {
+	h.t.Helper()
+	return h.loginAt("/auth/oidc/login", claims)
+}
+
+// loginAt goes through the authorization code flow started at the path
+func (h *harness) loginAt(path string, claims map[string]any) *httptest.ResponseRecorder {
+	h.t.Helper()
+
+	rec := h.get(path, nil)
+	if rec.Code != http.StatusFound {
+		h.t.Fatalf("login: %d %s", rec.Code, rec.Body)
+	}
+	location, err := url.Parse(rec.Header().Get(echo.HeaderLocation))
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	query := location.Query()
+	if query.Get("client_id") != testClientID || query.Get("code_challenge_method") != "S256" {
+		h.t.Fatalf("unexpected authorization request %s", location)
+	}
+
+	// The user logs in at the issuer, which redirects back with a code
+	code := h.issuer.authorize(query.Get("nonce"), query.Get("code_challenge"), claims)
+	callback := "/auth/oidc/callback?" + url.Values{"state": {query.Get("state")}, "code": {code}}.Encode()
+
+	return h.get(callback, rec.Result().Cookies())
+}
+
+// get sends a request with the cookies
+func (h *harness) get(path string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	h.echo.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// postForm sends a form
+func (h *harness) postForm(path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	h.echo.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// assertSession checks the response holds an access token and returns its
+// user
+func (h *harness) assertSession(rec *httptest.ResponseRecorder) *models.User {
+	h.t.Helper()
+
+	var tokens struct {
+		AccessToken string `json:"accessToken"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &tokens); err != nil {
+		h.t.Fatal(err)
+	}
+	identity, err := h.auth.AuthenticateSession(tokens.AccessToken)
+	if err != nil {
+		h.t.Fatalf("invalid access token: %v", err)
+	}
+
+	var user models.User
+	if err := h.db.First(&user, "id = ?", identity.UserID).Error; err != nil {
+		h.t.Fatal(err)
+	}
+
+	return &user
+}
+
+// createLocalUser creates a user with a password
+func (h *harness) createLocalUser(username, email string) *models.User {
+	h.t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	user := &models.User{ID: username + "-id", Username: username, Email: email, Password: string(hash), Role: models.RoleUser}
+	if err := h.db.Create(user).Error; err != nil {
+		h.t.Fatal(err)
+	}
+
+	return user
+}
+
+// testIssuer is an OpenID Connect provider serving a discovery document, a
+// JWKS and a token endpoint, issuing RS256 ID tokens
+type testIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+// authorization is a pending authorization code
+type authorization struct {
+	nonce     string
+	challenge string
+	claims    map[string]any
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer := &testIssuer{t: t, key: key, codes: make(map[string]authorization)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                issuer.server.URL,
+			"authorization_endpoint":                issuer.server.URL + "/authorize",
+			"token_endpoint":                        issuer.server.URL + "/token",
+			"jwks_uri":                              issuer.server.URL + "/jwks",
+			"response_types_supported":              []string{"code"},
+			"subject_types_supported":               []string{"public"},
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", issuer.token)
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+// authorize returns a code for a login with the claims
+func (i *testIssuer) authorize(nonce, challenge string, claims map[string]any) string {
+	code, err := randomString()
+	if err != nil {
+		i.t.Fatal(err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.codes[code] = authorization{nonce: nonce, challenge: challenge, claims: claims}
+
+	return code
+}
+
+// token exchanges a code for an ID token, checking the PKCE verifier
+func (i *testIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	i.mu.Lock()
+	pending, ok := i.codes[r.PostForm.Get("code")]
+	delete(i.codes, r.PostForm.Get("code"))
+	i.mu.Unlock()
+
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(verifier[:]) != pending.challenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := map[string]any{
+		"iss":   i.server.URL,
+		"aud":   testClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Minute).Unix(),
+		"nonce": pending.nonce,
+	}
+	for name, value := range pending.claims {
+		claims[name] = value
+	}
+
+	writeJSON(w, map[string]any{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     i.sign(claims),
+	})
+}
+
+// sign returns the claims as a JWT signed with RS256
+func (i *testIssuer) sign(claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": "test", "typ": "JWT"})
+	if err != nil {
+		i.t.Fatal(err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		i.t.Fatal(err)
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	if err != nil {
+		i.t.Fatal(err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// writeJSON writes the value as a JSON response
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
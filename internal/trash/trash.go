@@ -0,0 +1,84 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// Restorable reports whether a record deleted at deletedAt is still in the
+// trash. A zero retention keeps deleted records forever.
+func Restorable(deletedAt time.Time, retention time.Duration) bool {
+	return retention <= 0 || time.Since(deletedAt) < retention
+}
+
+// Purge permanently deletes the emails and aliases that have been in the
+// trash for longer than the retention. Those of deleted users are erased
+// with the user at the end of its deletion grace period instead.
+func Purge(db *gorm.DB, retention time.Duration) error {
+	if retention <= 0 {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-retention)
+	deletedUsers := db.Unscoped().Model(&models.User{}).Select("id").Where("deleted_at IS NOT NULL")
+	for _, model := range []any{&models.Alias{}, &models.Email{}} {
+		result := db.Unscoped().
+			Where("deleted_at IS NOT NULL AND deleted_at < ? AND user_id NOT IN (?)", cutoff, deletedUsers).
+			Delete(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			log.Info().Int64("count", result.RowsAffected).Str("table", tableName(db, model)).Msg("Purged trash")
+		}
+	}
+
+	// Notifications and credentials go with their user
+	users := db.Unscoped().Model(&models.User{}).Select("id")
+	for _, model := range []any{
+		&models.Notification{}, &models.Session{}, &models.PersonalAccessToken{},
+		&models.PasswordReset{}, &models.WebAuthnCredential{},
+	} {
+		if err := db.Where("user_id NOT IN (?)", users).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunPurger purges the trash every interval until the context is cancelled
+func RunPurger(ctx context.Context, db *gorm.DB, retention, interval time.Duration) {
+	if retention <= 0 || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := Purge(db, retention); err != nil {
+			log.Error().Err(err).Msg("Failed to purge trash")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tableName returns the table of a model for logging
+func tableName(db *gorm.DB, model any) string {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return ""
+	}
+
+	return stmt.Schema.Table
+}
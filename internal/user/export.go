@@ -0,0 +1,66 @@
+package user
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/export"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is the maximum payload of a streamed export chunk
+const exportChunkSize = 64 * 1024
+
+// ExportUserData streams everything stored about a user as a JSON document
+// or a ZIP archive
+func (s *Service) ExportUserData(req *aliasme.ExportUserDataRequest, stream aliasme.UserService_ExportUserDataServer) error {
+	userID, err := auth.ActingUser(stream.Context(), req.UserId)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := export.Collect(s.db, userID)
+	if err != nil {
+		if errors.Is(err, export.ErrUserNotFound) {
+			return status.Error(codes.NotFound, "user not found")
+		}
+		log.Error().Err(err).Msg("Failed to collect user data")
+		return status.Error(codes.Internal, "failed to collect user data")
+	}
+
+	var buf bytes.Buffer
+	first := &aliasme.ExportUserDataChunk{SchemaVersion: export.SchemaVersion}
+	switch req.Format {
+	case aliasme.ExportFormat_EXPORT_FORMAT_UNSPECIFIED, aliasme.ExportFormat_EXPORT_FORMAT_JSON:
+		err = export.WriteJSON(&buf, bundle)
+		first.Filename = fmt.Sprintf("aliasme-export-%s.json", bundle.UserID)
+		first.ContentType = "application/json"
+	case aliasme.ExportFormat_EXPORT_FORMAT_ZIP:
+		err = export.WriteZIP(&buf, bundle)
+		first.Filename = fmt.Sprintf("aliasme-export-%s.zip", bundle.UserID)
+		first.ContentType = "application/zip"
+	default:
+		return status.Errorf(codes.InvalidArgument, "unsupported export format %s", req.Format)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode user data")
+		return status.Error(codes.Internal, "failed to encode user data")
+	}
+
+	chunk := first
+	for {
+		chunk.Data = buf.Next(exportChunkSize)
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+		if buf.Len() == 0 {
+			return nil
+		}
+		chunk = &aliasme.ExportUserDataChunk{}
+	}
+}
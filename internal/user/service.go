@@ -19,7 +19,10 @@ import (
 	"errors"
 	"time"
 
+	"github.com/golgoth31/aliasme/internal/auth"
 	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/plan"
+	"github.com/golgoth31/aliasme/internal/utils"
 	aliasme "github.com/golgoth31/aliasme/pkg/proto"
 	"github.com/rs/xid"
 	"github.com/rs/zerolog/log"
@@ -30,19 +33,58 @@ import (
 	"gorm.io/gorm"
 )
 
+// restoreTokenLength is the length of the tokens of the links restoring
+// deleted accounts
+const restoreTokenLength = 32
+
 // Service handles user-related operations
 type Service struct {
 	aliasme.UnimplementedUserServiceServer
-	db *gorm.DB
+	db      *gorm.DB
+	plans   *plan.Catalog
+	config  Config
+	aliases Aliases
+	mailer  Mailer
+}
+
+// Config holds the user service configuration
+type Config struct {
+	// DeletionGracePeriod is how long deleted users can be restored before
+	// they are erased. Zero erases them right away.
+	DeletionGracePeriod time.Duration
+	// Password is the policy of the passwords of new users
+	Password auth.PasswordPolicy
+	// Secret keys the hashes of the restore tokens
+	Secret []byte
+}
+
+// Aliases trashes and restores the emails and aliases of deleted users, and
+// their OVH redirections outside of the transactions
+type Aliases interface {
+	TrashUserAliases(tx *gorm.DB, userID, deletionID string, deletedAt time.Time) error
+	RestoreUserAliases(tx *gorm.DB, userID, deletionID string) error
+	RemoveUserRedirections(userID string) ([]models.Alias, error)
+	ProvisionRedirections(aliases []models.Alias) error
+	ProvisionUserRedirections(userID string) error
+}
+
+// Mailer sends the emails of account deletion
+type Mailer interface {
+	SendAccountDeletion(tx *gorm.DB, to, token, locale string, deleteAt time.Time) error
+	SendAccountDeleted(tx *gorm.DB, to, locale string) error
 }
 
 // New creates a new user service
-func New(db *gorm.DB) *Service {
-	return &Service{db: db}
+func New(db *gorm.DB, plans *plan.Catalog, cfg Config, aliases Aliases, mailer Mailer) *Service {
+	return &Service{db: db, plans: plans, config: cfg, aliases: aliases, mailer: mailer}
 }
 
 // CreateUser creates a new user
 func (s *Service) CreateUser(ctx context.Context, req *aliasme.CreateUserRequest) (*aliasme.User, error) {
+	if err := s.config.Password.Check(req.Password); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -62,6 +104,8 @@ func (s *Service) CreateUser(ctx context.Context, req *aliasme.CreateUserRequest
 		Username:  req.Username,
 		Email:     req.Email,
 		Password:  string(hashedPassword),
+		Locale:    req.Locale,
+		Role:      models.RoleUser,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -71,42 +115,95 @@ func (s *Service) CreateUser(ctx context.Context, req *aliasme.CreateUserRequest
 		return nil, err
 	}
 
-	return &aliasme.User{
-		Id:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: timestamppb.New(user.CreatedAt),
-		UpdatedAt: timestamppb.New(user.UpdatedAt),
-	}, nil
+	return userToProto(user), nil
 }
 
 // GetUser retrieves a user by ID
 func (s *Service) GetUser(ctx context.Context, req *aliasme.GetUserRequest) (*aliasme.User, error) {
+	if err := auth.Authorize(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
 	var user models.User
 	if err := s.db.First(&user, "id = ?", req.Id).Error; err != nil {
 		log.Error().Err(err).Msg("Failed to get user")
 		return nil, err
 	}
 
-	return &aliasme.User{
-		Id:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: timestamppb.New(user.CreatedAt),
-		UpdatedAt: timestamppb.New(user.UpdatedAt),
-	}, nil
+	return userToProto(&user), nil
 }
 
-// UpdateUser updates a user
+// UpdateUser updates the user fields selected by the update mask. Only admins
+// may change the plan and the role, and users changing their own email need
+// their current password.
 func (s *Service) UpdateUser(ctx context.Context, req *aliasme.UpdateUserRequest) (*aliasme.User, error) {
+	if err := auth.Authorize(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	paths, err := utils.UpdatePaths(req.UpdateMask, map[string]bool{
+		"username":      req.Username != "",
+		"email":         req.Email != "",
+		"plan":          req.Plan != "",
+		"locale":        req.Locale != "",
+		"notifications": req.Notifications != "",
+		"role":          req.Role != "",
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if paths["plan"] || paths["role"] {
+		if err := auth.RequireAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if paths["username"] && req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username cannot be empty")
+	}
+	if paths["email"] && req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email cannot be empty")
+	}
+
 	var user models.User
 	if err := s.db.First(&user, "id = ?", req.Id).Error; err != nil {
 		log.Error().Err(err).Msg("Failed to get user")
 		return nil, err
 	}
 
-	user.Username = req.Username
-	user.Email = req.Email
+	if paths["username"] {
+		user.Username = req.Username
+	}
+	if paths["email"] && req.Email != user.Email {
+		if err := checkEmailChange(ctx, &user, req.CurrentPassword); err != nil {
+			return nil, err
+		}
+		user.Email = req.Email
+	}
+	if paths["plan"] {
+		if req.Plan != "" && !s.plans.Exists(req.Plan) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown plan %q", req.Plan)
+		}
+		user.Plan = req.Plan
+	}
+	if paths["locale"] {
+		user.Locale = req.Locale
+	}
+	if paths["notifications"] {
+		switch req.Notifications {
+		case "", models.NotificationsImmediate, models.NotificationsDigest, models.NotificationsOff:
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown notifications mode %q", req.Notifications)
+		}
+		user.Notifications = req.Notifications
+	}
+	if paths["role"] {
+		switch req.Role {
+		case models.RoleUser, models.RoleAdmin:
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown role %q", req.Role)
+		}
+		user.Role = req.Role
+	}
 	user.UpdatedAt = time.Now()
 
 	if err := s.db.Save(&user).Error; err != nil {
@@ -114,23 +211,110 @@ func (s *Service) UpdateUser(ctx context.Context, req *aliasme.UpdateUserRequest
 		return nil, err
 	}
 
-	return &aliasme.User{
-		Id:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: timestamppb.New(user.CreatedAt),
-		UpdatedAt: timestamppb.New(user.UpdatedAt),
-	}, nil
+	return userToProto(&user), nil
+}
+
+// checkEmailChange checks the current password of users changing their own
+// email, where password reset links are sent, so that a stolen access token
+// cannot take the account over. Admins changing the email of another user need
+// none.
+func checkEmailChange(ctx context.Context, user *models.User, password string) error {
+	identity, _ := auth.FromContext(ctx)
+	if identity.UserID != user.ID {
+		return nil
+	}
+	// Single sign-on users have no password
+	if user.OIDCSubject != "" {
+		return status.Error(codes.FailedPrecondition, "the email of single sign-on accounts is managed by the identity provider")
+	}
+	if password == "" {
+		return status.Error(codes.InvalidArgument, "current_password is required to change the email")
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		return status.Error(codes.PermissionDenied, "current password is incorrect")
+	}
+
+	return nil
 }
 
-// DeleteUser deletes a user
+// DeleteUser deletes a user: their aliases stop forwarding, their emails and
+// aliases go to the trash and their credentials are revoked. The user is
+// erased at the end of the grace period, or right away without one.
 func (s *Service) DeleteUser(ctx context.Context, req *aliasme.DeleteUserRequest) (*aliasme.DeleteUserResponse, error) {
-	if err := s.db.Delete(&models.User{}, "id = ?", req.Id).Error; err != nil {
-		log.Error().Err(err).Msg("Failed to delete user")
+	if err := auth.Authorize(ctx, req.Id); err != nil {
 		return nil, err
 	}
 
-	return &aliasme.DeleteUserResponse{Success: true}, nil
+	var user models.User
+	if err := s.db.First(&user, "id = ?", req.Id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		log.Error().Err(err).Msg("Failed to get user")
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+
+	// The redirections are removed before the transaction, and provisioned
+	// again if the deletion fails
+	removed, err := s.aliases.RemoveUserRedirections(user.ID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID).Msg("Failed to remove alias redirections")
+		s.provisionAgain(user.ID, removed)
+		return nil, status.Error(codes.Internal, "failed to delete user")
+	}
+
+	now := time.Now()
+	grace := s.config.DeletionGracePeriod
+	token := utils.GenerateRandomString(restoreTokenLength)
+	deletionID := xid.New().String()
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// Queued first, so the mail is encrypted with the OpenPGP key of
+		// the email before it goes to the trash
+		if grace > 0 {
+			if err := s.mailer.SendAccountDeletion(tx, user.Email, token, user.Locale, now.Add(grace)); err != nil {
+				return err
+			}
+		} else if err := s.mailer.SendAccountDeleted(tx, user.Email, user.Locale); err != nil {
+			return err
+		}
+
+		if err := auth.RevokeCredentials(tx, user.ID); err != nil {
+			return err
+		}
+		if err := s.aliases.TrashUserAliases(tx, user.ID, deletionID, now); err != nil {
+			return err
+		}
+
+		if grace <= 0 {
+			return erase(tx, user.ID)
+		}
+		return tx.Model(&models.User{}).Where("id = ?", user.ID).Updates(map[string]any{
+			"restore_token_hash": utils.HashToken(s.config.Secret, token),
+			"deletion_id":        deletionID,
+			"deleted_at":         now,
+		}).Error
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID).Msg("Failed to delete user")
+		s.provisionAgain(user.ID, removed)
+		return nil, status.Error(codes.Internal, "failed to delete user")
+	}
+	log.Info().Str("user_id", user.ID).Dur("grace_period", grace).Msg("Deleted user")
+
+	resp := &aliasme.DeleteUserResponse{Success: true}
+	if grace > 0 {
+		resp.RestorableUntil = timestamppb.New(now.Add(grace))
+	}
+
+	return resp, nil
+}
+
+// provisionAgain provisions the redirections removed for a deletion which
+// failed
+func (s *Service) provisionAgain(userID string, removed []models.Alias) {
+	if err := s.aliases.ProvisionRedirections(removed); err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("Failed to provision alias redirections again")
+	}
 }
 
 // generateID generates a random ID using xid
@@ -138,23 +322,34 @@ func generateID() (string, error) {
 	return xid.New().String(), nil
 }
 
-// GetUserByEmail retrieves a user ID by email
+// GetUserByEmail retrieves a user ID by email. Non-admins may only look up
+// their own email, so unknown emails are not told apart from others' emails.
 func (s *Service) GetUserByEmail(ctx context.Context, req *aliasme.GetUserByEmailRequest) (*aliasme.GetUserByEmailResponse, error) {
 	var user models.User
 	if err := s.db.Where("email = ?", req.Email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := auth.RequireAdmin(ctx); err != nil {
+				return nil, err
+			}
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
 		return nil, status.Error(codes.Internal, "failed to get user")
 	}
+	if err := auth.Authorize(ctx, user.ID); err != nil {
+		return nil, err
+	}
 
 	return &aliasme.GetUserByEmailResponse{
 		UserId: user.ID,
 	}, nil
 }
 
-// ListUsers retrieves all users
+// ListUsers retrieves all users, for admins only
 func (s *Service) ListUsers(ctx context.Context, req *aliasme.ListUsersRequest) (*aliasme.ListUsersResponse, error) {
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
 	var users []models.User
 	if err := s.db.Find(&users).Error; err != nil {
 		log.Error().Err(err).Msg("Failed to list users")
@@ -163,16 +358,204 @@ func (s *Service) ListUsers(ctx context.Context, req *aliasme.ListUsersRequest)
 
 	protoUsers := make([]*aliasme.User, len(users))
 	for i, user := range users {
-		protoUsers[i] = &aliasme.User{
-			Id:        user.ID,
-			Username:  user.Username,
-			Email:     user.Email,
-			CreatedAt: timestamppb.New(user.CreatedAt),
-			UpdatedAt: timestamppb.New(user.UpdatedAt),
-		}
+		protoUsers[i] = userToProto(&user)
 	}
 
 	return &aliasme.ListUsersResponse{
 		Users: protoUsers,
 	}, nil
 }
+
+// UndeleteUser restores a deleted user within the deletion grace period
+func (s *Service) UndeleteUser(ctx context.Context, req *aliasme.UndeleteUserRequest) (*aliasme.User, error) {
+	if err := auth.Authorize(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	if err := s.db.Unscoped().First(&user, "id = ? AND deleted_at IS NOT NULL", req.Id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "deleted user not found")
+		}
+		log.Error().Err(err).Msg("Failed to get deleted user")
+		return nil, status.Error(codes.Internal, "failed to get deleted user")
+	}
+
+	if !s.restorable(&user) {
+		return nil, status.Error(codes.FailedPrecondition, "user is past the deletion grace period")
+	}
+
+	return s.restore(&user)
+}
+
+// RestoreUser restores a deleted user with the token emailed on deletion
+func (s *Service) RestoreUser(ctx context.Context, req *aliasme.RestoreUserRequest) (*aliasme.User, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	var user models.User
+	err := s.db.Unscoped().
+		First(&user, "restore_token_hash = ? AND deleted_at IS NOT NULL", utils.HashToken(s.config.Secret, req.Token)).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "invalid restore token")
+		}
+		log.Error().Err(err).Msg("Failed to get deleted user")
+		return nil, status.Error(codes.Internal, "failed to get deleted user")
+	}
+
+	if !s.restorable(&user) {
+		return nil, status.Error(codes.DeadlineExceeded, "restore token expired")
+	}
+
+	return s.restore(&user)
+}
+
+// restorable reports whether a deleted user is still in the grace period
+func (s *Service) restorable(user *models.User) bool {
+	return time.Since(user.DeletedAt.Time) < s.config.DeletionGracePeriod
+}
+
+// restore restores a deleted user with the emails and aliases deleted with
+// them. Credentials stay revoked. The OVH redirections are provisioned after
+// the commit; when that fails the user goes back to the trash, and the
+// restore can be retried.
+func (s *Service) restore(user *models.User) (*aliasme.User, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&models.User{}).
+			Where("id = ? AND deleted_at IS NOT NULL", user.ID).
+			Updates(map[string]any{"deleted_at": nil, "restore_token_hash": "", "deletion_id": ""})
+		if result.Error != nil {
+			return result.Error
+		}
+		// Restored or erased meanwhile
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return s.aliases.RestoreUserAliases(tx, user.ID, user.DeletionID)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "deleted user not found")
+		}
+		log.Error().Err(err).Str("user_id", user.ID).Msg("Failed to restore user")
+		return nil, status.Error(codes.Internal, "failed to restore user")
+	}
+
+	if err := s.aliases.ProvisionUserRedirections(user.ID); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID).Msg("Failed to provision alias redirections")
+		s.revertRestore(user)
+		return nil, status.Error(codes.Internal, "failed to restore user")
+	}
+	log.Info().Str("user_id", user.ID).Msg("Restored user")
+
+	user.DeletedAt = gorm.DeletedAt{}
+	return userToProto(user), nil
+}
+
+// revertRestore puts a user whose restore failed back in the trash, with
+// their emails and aliases, keeping their restore token
+func (s *Service) revertRestore(user *models.User) {
+	// Redirections provisioned before the failure
+	if _, err := s.aliases.RemoveUserRedirections(user.ID); err != nil {
+		log.Error().Err(err).Str("user_id", user.ID).Msg("Failed to remove alias redirections")
+	}
+
+	deletedAt := user.DeletedAt.Time
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", user.ID).Updates(map[string]any{
+			"deleted_at":         deletedAt,
+			"restore_token_hash": user.RestoreTokenHash,
+			"deletion_id":        user.DeletionID,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return s.aliases.TrashUserAliases(tx, user.ID, user.DeletionID, deletedAt)
+	})
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.ID).Msg("Failed to revert user restore")
+	}
+}
+
+// RunDeletions erases the deleted users past the grace period every interval
+// until the context is cancelled
+func (s *Service) RunDeletions(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.eraseExpired()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// eraseExpired erases the deleted users past the grace period and confirms
+// it to them by email
+func (s *Service) eraseExpired() {
+	var users []models.User
+	err := s.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", time.Now().Add(-s.config.DeletionGracePeriod)).
+		Find(&users).Error
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list deleted users")
+		return
+	}
+
+	for _, user := range users {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			if err := s.mailer.SendAccountDeleted(tx, user.Email, user.Locale); err != nil {
+				return err
+			}
+			return erase(tx, user.ID)
+		})
+		if err != nil {
+			log.Error().Err(err).Str("user_id", user.ID).Msg("Failed to erase deleted user")
+			continue
+		}
+		log.Info().Str("user_id", user.ID).Msg("Erased deleted user")
+	}
+}
+
+// erase permanently deletes a user and everything stored about them within
+// the transaction. Quarantined addresses are kept until their release.
+func erase(tx *gorm.DB, userID string) error {
+	for _, model := range []any{
+		&models.Alias{}, &models.Email{}, &models.Notification{}, &models.Session{},
+		&models.PersonalAccessToken{}, &models.PasswordReset{}, &models.WebAuthnCredential{},
+		&models.WebAuthnChallenge{}, &models.DeviceLogin{},
+	} {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Unscoped().Delete(&models.User{}, "id = ?", userID).Error
+}
+
+// userToProto converts a user model to its protobuf representation
+func userToProto(user *models.User) *aliasme.User {
+	return &aliasme.User{
+		Id:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Plan:          user.Plan,
+		Locale:        user.Locale,
+		Notifications: user.Notifications,
+		Role:          user.Role,
+		TotpEnabled:   user.TOTPEnabled,
+		CreatedAt:     timestamppb.New(user.CreatedAt),
+		UpdatedAt:     timestamppb.New(user.UpdatedAt),
+	}
+}
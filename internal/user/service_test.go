@@ -0,0 +1,552 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/database"
+	"github.com/golgoth31/aliasme/internal/email"
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/golgoth31/aliasme/internal/ovh"
+	"github.com/golgoth31/aliasme/internal/plan"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// testPassword is the password of the users created by the harness
+const testPassword = "correct horse battery"
+
+type harness struct {
+	db     *gorm.DB
+	users  *Service
+	emails *email.EmailService
+	ovh    *fakeOVH
+}
+
+// fakeOVH serves the email redirection endpoints of the OVH API, keeping the
+// redirections in memory
+type fakeOVH struct {
+	mu sync.Mutex
+	// redirections maps the ID of each redirection to its source and target
+	redirections map[string][2]string
+	next         int
+}
+
+func newFakeOVH(t *testing.T) (*fakeOVH, *ovh.Client) {
+	t.Helper()
+
+	f := &fakeOVH{redirections: map[string][2]string{}}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	client, err := ovh.NewClient(server.URL, "key", "secret", "consumer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return f, client
+}
+
+func (f *fakeOVH) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/auth/time" {
+		json.NewEncoder(w).Encode(time.Now().Unix())
+		return
+	}
+	// /email/domain/{domain}/redirection[/{id}[/changeRedirection]]
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] != "email" || parts[1] != "domain" || parts[3] != "redirection" {
+		http.NotFound(w, r)
+		return
+	}
+
+	var body struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch {
+	case len(parts) == 4 && r.Method == http.MethodGet:
+		ids := []string{}
+		for id, redirection := range f.redirections {
+			if redirection[0] == r.URL.Query().Get("from") {
+				ids = append(ids, id)
+			}
+		}
+		json.NewEncoder(w).Encode(ids)
+	case len(parts) == 4 && r.Method == http.MethodPost:
+		f.next++
+		f.redirections[strconv.Itoa(f.next)] = [2]string{body.From, body.To}
+		json.NewEncoder(w).Encode(map[string]string{})
+	case len(parts) == 6 && parts[5] == "changeRedirection" && r.Method == http.MethodPost:
+		redirection, ok := f.redirections[parts[4]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		f.redirections[parts[4]] = [2]string{redirection[0], body.To}
+		json.NewEncoder(w).Encode(map[string]string{})
+	case len(parts) == 5 && r.Method == http.MethodDelete:
+		delete(f.redirections, parts[4])
+		json.NewEncoder(w).Encode(map[string]string{})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// targets returns the target of each redirected address
+func (f *fakeOVH) targets() map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	targets := map[string]string{}
+	for _, redirection := range f.redirections {
+		targets[redirection[0]] = redirection[1]
+	}
+
+	return targets
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	db, err := database.New(&database.Config{Path: filepath.Join(t.TempDir(), "aliasme.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("test secret")
+	plans := plan.NewCatalog(plan.Config{})
+	// Mail is only queued, the queue is not run
+	mailer := email.New(db, email.Config{
+		FromEmail:     "noreply@example.com",
+		BaseURL:       "http://localhost:8080",
+		Secret:        secret,
+		DefaultLocale: "en",
+	}, nil)
+	fake, client := newFakeOVH(t)
+	emails := email.NewEmailService(db, client, mailer, email.AliasConfig{Domains: []string{"example.net"}, QuarantinePeriod: 24 * time.Hour}, plans)
+
+	return &harness{
+		db:  db,
+		ovh: fake,
+		users: New(db, plans, Config{
+			DeletionGracePeriod: time.Hour,
+			Password:            auth.PasswordPolicy{MinLength: 8},
+			Secret:              secret,
+		}, emails, mailer),
+		emails: emails,
+	}
+}
+
+// createUser signs a user up with testPassword
+func (h *harness) createUser(t *testing.T, username string) *models.User {
+	t.Helper()
+
+	created, err := h.users.CreateUser(context.Background(), &aliasme.CreateUserRequest{
+		Username: username,
+		Email:    username + "@example.org",
+		Password: testPassword,
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	var user models.User
+	if err := h.db.First(&user, "id = ?", created.Id).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return &user
+}
+
+// as returns the context of a login session of the user
+func as(user *models.User) context.Context {
+	return auth.WithIdentity(context.Background(), &auth.Identity{UserID: user.ID, SessionID: "session", Role: user.Role})
+}
+
+// assertCode fails the test unless err has the code
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+
+	if got := status.Code(err); got != want {
+		t.Fatalf("error = %v, want %s", err, want)
+	}
+}
+
+func TestUsersCannotAccessOtherAccounts(t *testing.T) {
+	h := newHarness(t)
+	alice := h.createUser(t, "alice")
+	bob := h.createUser(t, "bob")
+	ctx := as(alice)
+
+	calls := map[string]func() error{
+		"GetUser": func() error {
+			_, err := h.users.GetUser(ctx, &aliasme.GetUserRequest{Id: bob.ID})
+			return err
+		},
+		"UpdateUser": func() error {
+			_, err := h.users.UpdateUser(ctx, &aliasme.UpdateUserRequest{Id: bob.ID, Username: "mallory"})
+			return err
+		},
+		"DeleteUser": func() error {
+			_, err := h.users.DeleteUser(ctx, &aliasme.DeleteUserRequest{Id: bob.ID})
+			return err
+		},
+		"UndeleteUser": func() error {
+			_, err := h.users.UndeleteUser(ctx, &aliasme.UndeleteUserRequest{Id: bob.ID})
+			return err
+		},
+		"GetUserByEmail": func() error {
+			_, err := h.users.GetUserByEmail(ctx, &aliasme.GetUserByEmailRequest{Email: bob.Email})
+			return err
+		},
+		"GetUserByEmail unknown": func() error {
+			_, err := h.users.GetUserByEmail(ctx, &aliasme.GetUserByEmailRequest{Email: "nobody@example.org"})
+			return err
+		},
+		"ListUsers": func() error {
+			_, err := h.users.ListUsers(ctx, &aliasme.ListUsersRequest{})
+			return err
+		},
+		"UpdateUser plan": func() error {
+			_, err := h.users.UpdateUser(ctx, &aliasme.UpdateUserRequest{Id: alice.ID, Plan: "pro"})
+			return err
+		},
+		"UpdateUser role": func() error {
+			_, err := h.users.UpdateUser(ctx, &aliasme.UpdateUserRequest{Id: alice.ID, Role: models.RoleAdmin})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			assertCode(t, call(), codes.PermissionDenied)
+		})
+	}
+
+	var stored models.User
+	if err := h.db.First(&stored, "id = ?", bob.ID).Error; err != nil {
+		t.Fatalf("bob was deleted: %v", err)
+	}
+	if stored.Username != "bob" {
+		t.Fatalf("bob was renamed %q", stored.Username)
+	}
+	var self models.User
+	if err := h.db.First(&self, "id = ?", alice.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if self.Role != models.RoleUser {
+		t.Fatalf("alice got the %s role", self.Role)
+	}
+
+	// Their own account is fine
+	if _, err := h.users.GetUser(ctx, &aliasme.GetUserRequest{Id: alice.ID}); err != nil {
+		t.Fatalf("GetUser of self: %v", err)
+	}
+}
+
+func TestAdminsAccessEveryAccount(t *testing.T) {
+	h := newHarness(t)
+	admin := h.createUser(t, "admin")
+	if err := h.db.Model(admin).Update("role", models.RoleAdmin).Error; err != nil {
+		t.Fatal(err)
+	}
+	bob := h.createUser(t, "bob")
+	ctx := as(admin)
+
+	if _, err := h.users.GetUser(ctx, &aliasme.GetUserRequest{Id: bob.ID}); err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	updated, err := h.users.UpdateUser(ctx, &aliasme.UpdateUserRequest{Id: bob.ID, Role: models.RoleAdmin})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.Role != models.RoleAdmin {
+		t.Fatalf("role = %s, want admin", updated.Role)
+	}
+	users, err := h.users.ListUsers(ctx, &aliasme.ListUsersRequest{})
+	if err != nil || len(users.Users) != 2 {
+		t.Fatalf("ListUsers: %v, %d users", err, len(users.GetUsers()))
+	}
+}
+
+func TestChangingOwnEmailNeedsThePassword(t *testing.T) {
+	h := newHarness(t)
+	alice := h.createUser(t, "alice")
+	ctx := as(alice)
+
+	tests := []struct {
+		name     string
+		password string
+		want     codes.Code
+	}{
+		{"missing", "", codes.InvalidArgument},
+		{"wrong", "not the password", codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		_, err := h.users.UpdateUser(ctx, &aliasme.UpdateUserRequest{
+			Id:              alice.ID,
+			Email:           "mallory@example.org",
+			CurrentPassword: tt.password,
+		})
+		assertCode(t, err, tt.want)
+	}
+
+	var stored models.User
+	if err := h.db.First(&stored, "id = ?", alice.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Email != alice.Email {
+		t.Fatalf("email changed to %s", stored.Email)
+	}
+
+	// Other fields need no password
+	if _, err := h.users.UpdateUser(ctx, &aliasme.UpdateUserRequest{Id: alice.ID, Locale: "fr"}); err != nil {
+		t.Fatalf("UpdateUser locale: %v", err)
+	}
+
+	updated, err := h.users.UpdateUser(ctx, &aliasme.UpdateUserRequest{
+		Id:              alice.ID,
+		Email:           "alice@example.com",
+		CurrentPassword: testPassword,
+	})
+	if err != nil {
+		t.Fatalf("UpdateUser with the password: %v", err)
+	}
+	if updated.Email != "alice@example.com" {
+		t.Fatalf("email = %s, want alice@example.com", updated.Email)
+	}
+}
+
+func TestAdminsChangeEmailsWithoutPassword(t *testing.T) {
+	h := newHarness(t)
+	admin := h.createUser(t, "admin")
+	if err := h.db.Model(admin).Update("role", models.RoleAdmin).Error; err != nil {
+		t.Fatal(err)
+	}
+	bob := h.createUser(t, "bob")
+
+	updated, err := h.users.UpdateUser(as(admin), &aliasme.UpdateUserRequest{Id: bob.ID, Email: "bob@example.com"})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.Email != "bob@example.com" {
+		t.Fatalf("email = %s, want bob@example.com", updated.Email)
+	}
+
+	// But their own email needs their password too
+	_, err = h.users.UpdateUser(as(admin), &aliasme.UpdateUserRequest{Id: admin.ID, Email: "root@example.com"})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestSingleSignOnUsersCannotChangeTheirEmail(t *testing.T) {
+	h := newHarness(t)
+	alice := h.createUser(t, "alice")
+	err := h.db.Model(alice).Updates(map[string]any{"oidc_issuer": "https://sso.example.org", "oidc_subject": "alice", "password": ""}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = h.users.UpdateUser(as(alice), &aliasme.UpdateUserRequest{Id: alice.ID, Email: "alice@example.com", CurrentPassword: testPassword})
+	assertCode(t, err, codes.FailedPrecondition)
+}
+
+// addAliases gives the user a verified email with two redirected aliases,
+// and an alias deleted on its own
+func (h *harness) addAliases(t *testing.T, user *models.User) ([]models.Alias, models.Alias) {
+	t.Helper()
+
+	destination := models.Email{ID: user.ID + "-email", UserID: user.ID, Address: user.Username + "@example.com", Verified: true}
+	aliases := []models.Alias{
+		{ID: user.ID + "-shop", UserID: user.ID, EmailID: destination.ID, AliasAddress: user.Username + ".shop@example.net"},
+		{ID: user.ID + "-news", UserID: user.ID, EmailID: destination.ID, AliasAddress: user.Username + ".news@example.net"},
+	}
+	deleted := models.Alias{
+		ID:           user.ID + "-old",
+		UserID:       user.ID,
+		EmailID:      destination.ID,
+		AliasAddress: user.Username + ".old@example.net",
+		DeletedAt:    gorm.DeletedAt{Time: time.Now().Add(-time.Minute), Valid: true},
+	}
+	for _, row := range []any{&destination, &aliases, &deleted} {
+		if err := h.db.Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := h.emails.ProvisionRedirections(aliases); err != nil {
+		t.Fatal(err)
+	}
+
+	return aliases, deleted
+}
+
+// liveAliases returns the IDs of the aliases of the user not in the trash
+func (h *harness) liveAliases(t *testing.T, user *models.User) []string {
+	t.Helper()
+
+	var ids []string
+	if err := h.db.Model(&models.Alias{}).Where("user_id = ?", user.ID).Order("id").Pluck("id", &ids).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return ids
+}
+
+func TestRestoreBringsBackWhatWasDeletedWithTheAccount(t *testing.T) {
+	h := newHarness(t)
+	alice := h.createUser(t, "alice")
+	aliases, deleted := h.addAliases(t, alice)
+	bob := h.createUser(t, "bob")
+	h.addAliases(t, bob)
+
+	if _, err := h.users.DeleteUser(as(alice), &aliasme.DeleteUserRequest{Id: alice.ID}); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if ids := h.liveAliases(t, alice); len(ids) != 0 {
+		t.Fatalf("aliases %v left after the deletion", ids)
+	}
+	for _, alias := range aliases {
+		if _, ok := h.ovh.targets()[alias.AliasAddress]; ok {
+			t.Fatalf("redirection of %s left after the deletion", alias.AliasAddress)
+		}
+	}
+
+	// An alias deleted on its own at the very same time stays in the trash
+	var stored models.User
+	if err := h.db.Unscoped().First(&stored, "id = ?", alice.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.DeletionID == "" {
+		t.Fatal("deletion is not tagged")
+	}
+	if err := h.db.Unscoped().Model(&deleted).Update("deleted_at", stored.DeletedAt.Time).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := h.users.UndeleteUser(as(alice), &aliasme.UndeleteUserRequest{Id: alice.ID})
+	if err != nil {
+		t.Fatalf("UndeleteUser: %v", err)
+	}
+	if restored.Id != alice.ID {
+		t.Fatalf("restored user %s, want %s", restored.Id, alice.ID)
+	}
+
+	if ids := h.liveAliases(t, alice); len(ids) != 2 || ids[0] != aliases[1].ID || ids[1] != aliases[0].ID {
+		t.Fatalf("restored aliases = %v, want %s and %s", ids, aliases[0].ID, aliases[1].ID)
+	}
+	targets := h.ovh.targets()
+	for _, alias := range aliases {
+		if target := targets[alias.AliasAddress]; target != "alice@example.com" {
+			t.Fatalf("%s redirects to %q, want alice@example.com", alias.AliasAddress, target)
+		}
+	}
+	if _, ok := targets[deleted.AliasAddress]; ok {
+		t.Fatalf("redirection of %s was restored", deleted.AliasAddress)
+	}
+	var emails int64
+	if err := h.db.Model(&models.Email{}).Where("user_id = ?", alice.ID).Count(&emails).Error; err != nil {
+		t.Fatal(err)
+	}
+	if emails != 1 {
+		t.Fatalf("%d emails restored, want 1", emails)
+	}
+	var tagged int64
+	for _, model := range []any{&models.User{}, &models.Email{}, &models.Alias{}} {
+		var count int64
+		if err := h.db.Unscoped().Model(model).Where("deletion_id <> ''").Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		tagged += count
+	}
+	if tagged != 0 {
+		t.Fatalf("%d rows still tagged with the deletion", tagged)
+	}
+
+	// Bob was not touched
+	if ids := h.liveAliases(t, bob); len(ids) != 2 {
+		t.Fatalf("bob has %d aliases, want 2", len(ids))
+	}
+	if target := targets["bob.shop@example.net"]; target != "bob@example.com" {
+		t.Fatalf("bob.shop@example.net redirects to %q, want bob@example.com", target)
+	}
+}
+
+func TestDeletedAccountsAreErasedAfterTheGracePeriod(t *testing.T) {
+	h := newHarness(t)
+	alice := h.createUser(t, "alice")
+	aliases, _ := h.addAliases(t, alice)
+	bob := h.createUser(t, "bob")
+	h.addAliases(t, bob)
+
+	if _, err := h.users.DeleteUser(as(alice), &aliasme.DeleteUserRequest{Id: alice.ID}); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	// Still within the grace period
+	h.users.eraseExpired()
+	var stored models.User
+	if err := h.db.Unscoped().First(&stored, "id = ?", alice.ID).Error; err != nil {
+		t.Fatalf("erased within the grace period: %v", err)
+	}
+
+	past := time.Now().Add(-h.users.config.DeletionGracePeriod - time.Minute)
+	if err := h.db.Unscoped().Model(&models.User{}).Where("id = ?", alice.ID).Update("deleted_at", past).Error; err != nil {
+		t.Fatal(err)
+	}
+	h.users.eraseExpired()
+
+	for _, model := range []any{&models.User{}, &models.Email{}, &models.Alias{}, &models.Session{}} {
+		column := "user_id"
+		if _, ok := model.(*models.User); ok {
+			column = "id"
+		}
+		var count int64
+		if err := h.db.Unscoped().Model(model).Where(column+" = ?", alice.ID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Fatalf("%d %T rows of alice left", count, model)
+		}
+	}
+
+	// The addresses stay quarantined, and the user is told
+	var quarantined int64
+	if err := h.db.Model(&models.QuarantinedAddress{}).Where("user_id = ?", alice.ID).Count(&quarantined).Error; err != nil {
+		t.Fatal(err)
+	}
+	if quarantined != int64(len(aliases)) {
+		t.Fatalf("%d addresses quarantined, want %d", quarantined, len(aliases))
+	}
+	var mails int64
+	if err := h.db.Model(&models.OutgoingMail{}).Where("recipient = ?", alice.Email).Count(&mails).Error; err != nil {
+		t.Fatal(err)
+	}
+	if mails != 2 {
+		t.Fatalf("%d mails to alice, want the deletion notice and the confirmation", mails)
+	}
+
+	// The restore is no longer possible, and bob was not touched
+	_, err := h.users.UndeleteUser(as(alice), &aliasme.UndeleteUserRequest{Id: alice.ID})
+	assertCode(t, err, codes.NotFound)
+	if ids := h.liveAliases(t, bob); len(ids) != 2 {
+		t.Fatalf("bob has %d aliases, want 2", len(ids))
+	}
+}
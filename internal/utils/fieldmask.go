@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// UpdatePaths returns the set of fields an update request applies to.
+// fields maps every updatable path to whether the request populates it. An
+// empty mask selects the populated fields and "*" selects all of them.
+func UpdatePaths(mask *fieldmaskpb.FieldMask, fields map[string]bool) (map[string]bool, error) {
+	paths := make(map[string]bool, len(fields))
+
+	if len(mask.GetPaths()) == 0 {
+		for path, populated := range fields {
+			if populated {
+				paths[path] = true
+			}
+		}
+		return paths, nil
+	}
+
+	for _, path := range mask.GetPaths() {
+		if path == "*" {
+			for field := range fields {
+				paths[field] = true
+			}
+			continue
+		}
+		if _, ok := fields[path]; !ok {
+			return nil, fmt.Errorf("unknown update_mask path %q", path)
+		}
+		paths[path] = true
+	}
+
+	return paths, nil
+}
@@ -1,29 +1,38 @@
 package utils
 
 import (
-	"math/rand"
+	"crypto/rand"
+	"math/big"
 )
 
 var (
 	letterRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
 	numberRunes = []rune("0123456789")
-	rnd         = rand.New(rand.NewSource(rand.Int63()))
 )
 
 // GenerateRandomString generates a random string of specified length
 func GenerateRandomString(length int) string {
-	b := make([]rune, length)
-	for i := range b {
-		b[i] = letterRunes[rnd.Intn(len(letterRunes))]
-	}
-	return string(b)
+	return randomRunes(letterRunes, length)
 }
 
-// GenerateRandomNumber generates a random numeric string of specified length
+// GenerateRandomNumber generates a random numeric string of specified length.
+// It is suitable for one-time codes.
 func GenerateRandomNumber(length int) string {
+	return randomRunes(numberRunes, length)
+}
+
+// randomRunes picks length runes from the alphabet using a cryptographically
+// secure source
+func randomRunes(alphabet []rune, length int) string {
+	max := big.NewInt(int64(len(alphabet)))
 	b := make([]rune, length)
 	for i := range b {
-		b[i] = numberRunes[rnd.Intn(len(numberRunes))]
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			// crypto/rand does not fail on supported platforms
+			panic(err)
+		}
+		b[i] = alphabet[n.Int64()]
 	}
 	return string(b)
 }
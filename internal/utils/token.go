@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// MinSecretLength is the minimum length in bytes of the server secret used to
+// hash tokens
+const MinSecretLength = 32
+
+// HashToken returns the hex encoded HMAC-SHA256 of a token keyed with the
+// server secret. Only this hash is stored, so database read access is not
+// enough to use a token.
+func HashToken(secret []byte, token string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// TokenMatches reports in constant time whether the token hashes to hash
+func TokenMatches(secret []byte, token, hash string) bool {
+	return hmac.Equal([]byte(HashToken(secret, token)), []byte(hash))
+}
+
+// DeriveKey derives a key dedicated to a purpose from the server secret, so
+// the secret itself is never used for more than one thing
+func DeriveKey(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
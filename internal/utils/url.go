@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ParseBaseURL validates an absolute http or https URL used to build public
+// links and returns it without trailing slash
+func ParseBaseURL(raw string) (string, error) {
+	if raw == "" {
+		return "", fmt.Errorf("base URL is required")
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("base URL must use http or https, got %q", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("base URL has no host: %q", raw)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("base URL must not have a query or fragment: %q", raw)
+	}
+
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
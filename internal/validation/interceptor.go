@@ -0,0 +1,135 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// validator is implemented by the messages generated by protoc-gen-validate
+type validator interface {
+	ValidateAll() error
+}
+
+// fieldError is a single rule violation of a generated validator
+type fieldError interface {
+	Field() string
+	Reason() string
+}
+
+// multiError wraps every rule violation of a message
+type multiError interface {
+	AllErrors() []error
+}
+
+// UnaryInterceptor rejects the requests of unary RPCs breaking the rules of
+// the proto definition
+func UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := Validate(req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor rejects the messages received by streaming RPCs breaking
+// the rules of the proto definition
+func StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatedStream{ServerStream: stream})
+	}
+}
+
+// validatedStream validates every message it receives
+type validatedStream struct {
+	grpc.ServerStream
+}
+
+// RecvMsg receives a message and validates it
+func (s *validatedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return Validate(m)
+}
+
+// Validate checks every rule of a message and returns an InvalidArgument
+// error listing the field violations. Messages without rules pass.
+func Validate(m any) error {
+	v, ok := m.(validator)
+	if !ok {
+		return nil
+	}
+	err := v.ValidateAll()
+	if err == nil {
+		return nil
+	}
+
+	msg, _ := m.(proto.Message)
+	violations := fieldViolations(msg, err)
+	descriptions := make([]string, len(violations))
+	for i, violation := range violations {
+		descriptions[i] = violation.Field + ": " + violation.Description
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(descriptions, "; "))
+	detailed, detailsErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailsErr != nil {
+		log.Error().Err(detailsErr).Msg("Failed to attach field violations")
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// fieldViolations lists the violations of a validation error, naming the
+// fields by their proto name
+func fieldViolations(msg proto.Message, err error) []*errdetails.BadRequest_FieldViolation {
+	var multi multiError
+	if errors.As(err, &multi) {
+		var violations []*errdetails.BadRequest_FieldViolation
+		for _, e := range multi.AllErrors() {
+			violations = append(violations, fieldViolations(msg, e)...)
+		}
+		return violations
+	}
+
+	var fe fieldError
+	if !errors.As(err, &fe) {
+		return []*errdetails.BadRequest_FieldViolation{{Description: err.Error()}}
+	}
+
+	return []*errdetails.BadRequest_FieldViolation{{Field: protoName(msg, fe.Field()), Description: fe.Reason()}}
+}
+
+// protoName converts a field name as reported by the generated validators,
+// e.g. AliasPrefix or Scopes[0], to its proto name
+func protoName(msg proto.Message, goName string) string {
+	if msg == nil {
+		return goName
+	}
+	name, index, found := strings.Cut(goName, "[")
+	if found {
+		index = "[" + index
+	}
+
+	fields := msg.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if strings.EqualFold(strings.ReplaceAll(string(fd.Name()), "_", ""), name) {
+			return string(fd.Name()) + index
+		}
+	}
+
+	return goName
+}
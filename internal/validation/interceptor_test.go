@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// violations returns the field violations attached to a validation error
+func violations(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("error = %v, want InvalidArgument", err)
+	}
+	fields := map[string]string{}
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, violation := range badRequest.FieldViolations {
+			fields[violation.Field] = violation.Description
+		}
+	}
+	if len(fields) == 0 {
+		t.Fatalf("no field violations in %v", err)
+	}
+
+	return fields
+}
+
+func TestValidateAcceptsValidMessages(t *testing.T) {
+	for _, m := range []any{
+		&aliasme.LoginRequest{Login: "bob", Password: "correct horse battery"},
+		&aliasme.CreateAliasRequest{EmailId: "email", AliasPrefix: "shop.news"},
+		// Without rules
+		&emptypb.Empty{},
+		"not a message",
+	} {
+		if err := Validate(m); err != nil {
+			t.Fatalf("Validate(%T) = %v, want nil", m, err)
+		}
+	}
+}
+
+func TestValidateListsEveryViolation(t *testing.T) {
+	err := Validate(&aliasme.LoginRequest{Password: strings.Repeat("a", 73)})
+
+	fields := violations(t, err)
+	if len(fields) != 2 || fields["login"] == "" || fields["password"] == "" {
+		t.Fatalf("violations = %v, want login and password", fields)
+	}
+	message := status.Convert(err).Message()
+	if !strings.Contains(message, "login: ") || !strings.Contains(message, "password: ") {
+		t.Fatalf("message = %q, want both fields", message)
+	}
+}
+
+func TestValidateNamesFieldsByProtoName(t *testing.T) {
+	fields := violations(t, Validate(&aliasme.CreateAliasRequest{EmailId: "email", AliasPrefix: "Not Valid"}))
+	if _, ok := fields["alias_prefix"]; !ok || len(fields) != 1 {
+		t.Fatalf("violations = %v, want alias_prefix", fields)
+	}
+
+	fields = violations(t, Validate(&aliasme.CreateTokenRequest{Name: "ci", Scopes: []string{"aliases", ""}}))
+	if _, ok := fields["scopes[1]"]; !ok || len(fields) != 1 {
+		t.Fatalf("violations = %v, want scopes[1]", fields)
+	}
+}
+
+func TestProtoName(t *testing.T) {
+	tests := []struct {
+		goName string
+		want   string
+	}{
+		{"AliasPrefix", "alias_prefix"},
+		{"EmailId", "email_id"},
+		{"Domain", "domain"},
+		{"Unknown", "Unknown"},
+	}
+	for _, tt := range tests {
+		if got := protoName(&aliasme.CreateAliasRequest{}, tt.goName); got != tt.want {
+			t.Fatalf("protoName(%q) = %q, want %q", tt.goName, got, tt.want)
+		}
+	}
+
+	if got := protoName(&aliasme.CreateTokenRequest{}, "Scopes[2]"); got != "scopes[2]" {
+		t.Fatalf("protoName(Scopes[2]) = %q, want scopes[2]", got)
+	}
+	if got := protoName(nil, "AliasPrefix"); got != "AliasPrefix" {
+		t.Fatalf("protoName without message = %q, want AliasPrefix", got)
+	}
+}
@@ -0,0 +1,157 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/golgoth31/aliasme/internal/auth"
+	"github.com/golgoth31/aliasme/internal/models"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Device login pages
+const (
+	pageDeviceCode     = "device_code"
+	pageDeviceForm     = "device_form"
+	pageDeviceApproved = "device_approved"
+	pageDeviceDenied   = "device_denied"
+)
+
+// invalidUserCode is shown for unknown, expired and already used user codes
+const invalidUserCode = "This code is invalid or has expired. Start the login on the device again."
+
+// DeviceApprover approves device logins once the user logged in
+type DeviceApprover interface {
+	VerifyLogin(login, password, code string) (*models.User, error)
+	ApproveDevice(userCode, userID string) error
+	ApproveDeviceWithToken(userCode, token string) error
+	DenyDevice(userCode string) error
+}
+
+// DeviceFormHandler serves the page where users approve device logins,
+// asking for the user code first when the link does not carry it
+func DeviceFormHandler(sso bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setDeviceHeaders(c)
+
+		userCode := c.QueryParam("user_code")
+		if userCode == "" {
+			return render(c, http.StatusOK, pageDeviceCode, nil)
+		}
+
+		return render(c, http.StatusOK, pageDeviceForm, map[string]any{
+			"UserCode": auth.FormatUserCode(userCode),
+			"SSO":      sso,
+		})
+	}
+}
+
+// SSODeviceForm serves the approval page to a user back from single sign-on,
+// who still has to approve the device login explicitly. The approval token
+// stands for their login.
+func SSODeviceForm(c echo.Context, userCode, username, approvalToken string) error {
+	setDeviceHeaders(c)
+
+	return render(c, http.StatusOK, pageDeviceForm, map[string]any{
+		"UserCode":      auth.FormatUserCode(userCode),
+		"Username":      username,
+		"ApprovalToken": approvalToken,
+	})
+}
+
+// DeviceHandler approves or denies the device login submitted with the form
+func DeviceHandler(approver DeviceApprover, sso bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setDeviceHeaders(c)
+
+		userCode := auth.FormatUserCode(c.FormValue("user_code"))
+		login := c.FormValue("login")
+		retry := func(message string) error {
+			return render(c, http.StatusBadRequest, pageDeviceForm, map[string]any{
+				"UserCode": userCode,
+				"Login":    login,
+				"SSO":      sso,
+				"Error":    message,
+			})
+		}
+
+		if c.FormValue("action") == "deny" {
+			err := approver.DenyDevice(userCode)
+			switch status.Code(err) {
+			case codes.OK:
+				return render(c, http.StatusOK, pageDeviceDenied, nil)
+			case codes.NotFound:
+				return render(c, http.StatusBadRequest, pageDeviceCode, map[string]string{"Error": invalidUserCode})
+			default:
+				log.Error().Err(err).Msg("Failed to deny device login")
+				return echo.NewHTTPError(http.StatusInternalServerError)
+			}
+		}
+
+		// Users back from single sign-on approve with their approval token
+		if token := c.FormValue("approval_token"); token != "" {
+			err := approver.ApproveDeviceWithToken(userCode, token)
+			switch status.Code(err) {
+			case codes.OK:
+				return render(c, http.StatusOK, pageDeviceApproved, nil)
+			case codes.NotFound:
+				return render(c, http.StatusBadRequest, pageDeviceCode, map[string]string{"Error": invalidUserCode})
+			case codes.Unauthenticated:
+				return retry("Your single sign-on login has expired, log in again.")
+			default:
+				log.Error().Err(err).Msg("Failed to approve device login")
+				return echo.NewHTTPError(http.StatusInternalServerError)
+			}
+		}
+
+		user, err := approver.VerifyLogin(login, c.FormValue("password"), c.FormValue("code"))
+		switch status.Code(err) {
+		case codes.OK:
+		case codes.FailedPrecondition:
+			return retry("Enter the code of your authenticator app or a recovery code.")
+		case codes.PermissionDenied:
+			if errors.Is(err, auth.ErrPasskeyRequired) {
+				return retry("Your account uses a passkey as second factor, approve with your passkey.")
+			}
+			log.Error().Err(err).Msg("Failed to verify login")
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		case codes.ResourceExhausted:
+			return retry("Too many invalid two-factor codes, try again later.")
+		case codes.InvalidArgument, codes.Unauthenticated:
+			return retry("Invalid login, password or two-factor code.")
+		default:
+			log.Error().Err(err).Msg("Failed to verify login")
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+
+		err = approver.ApproveDevice(userCode, user.ID)
+		switch status.Code(err) {
+		case codes.OK:
+			return render(c, http.StatusOK, pageDeviceApproved, nil)
+		case codes.NotFound:
+			return render(c, http.StatusBadRequest, pageDeviceCode, map[string]string{"Error": invalidUserCode})
+		default:
+			log.Error().Err(err).Msg("Failed to approve device login")
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
+}
+
+// DeviceApprovedHandler serves the page shown once a device login is approved
+// with a passkey
+func DeviceApprovedHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setDeviceHeaders(c)
+		return render(c, http.StatusOK, pageDeviceApproved, nil)
+	}
+}
+
+// setDeviceHeaders keeps user codes out of caches and referrers, and the
+// approval page out of frames
+func setDeviceHeaders(c echo.Context) {
+	setTokenHeaders(c)
+	c.Response().Header().Set("X-Frame-Options", "DENY")
+}
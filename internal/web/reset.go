@@ -0,0 +1,88 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golgoth31/aliasme/internal/validation"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Password reset pages
+const (
+	pageResetForm    = "reset_form"
+	pageResetSuccess = "reset_success"
+	pageResetExpired = "reset_expired"
+	pageResetInvalid = "reset_invalid"
+)
+
+// PasswordResetter resets passwords from their reset token
+type PasswordResetter interface {
+	ResetPassword(ctx context.Context, req *aliasme.ResetPasswordRequest) (*aliasme.ResetPasswordResponse, error)
+}
+
+// ResetFormHandler serves the form of the links sent in password reset emails
+func ResetFormHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setTokenHeaders(c)
+
+		token := c.QueryParam("token")
+		if token == "" {
+			return render(c, http.StatusBadRequest, pageResetInvalid, nil)
+		}
+
+		return render(c, http.StatusOK, pageResetForm, map[string]string{"Token": token})
+	}
+}
+
+// ResetHandler sets the password submitted with the reset form
+func ResetHandler(resetter PasswordResetter) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setTokenHeaders(c)
+
+		req := &aliasme.ResetPasswordRequest{
+			Token:       c.FormValue("token"),
+			NewPassword: c.FormValue("password"),
+		}
+		// The pages are not served through the validating interceptor
+		if err := validation.Validate(req); err != nil {
+			return render(c, http.StatusBadRequest, pageResetInvalid, nil)
+		}
+
+		if req.NewPassword != c.FormValue("confirm") {
+			return render(c, http.StatusBadRequest, pageResetForm, map[string]string{
+				"Token": req.Token,
+				"Error": "The passwords do not match.",
+			})
+		}
+
+		_, err := resetter.ResetPassword(c.Request().Context(), req)
+		switch status.Code(err) {
+		case codes.OK:
+			return render(c, http.StatusOK, pageResetSuccess, nil)
+		case codes.InvalidArgument:
+			// Rejected by the password policy, the token is still valid
+			return render(c, http.StatusBadRequest, pageResetForm, map[string]string{
+				"Token": req.Token,
+				"Error": status.Convert(err).Message(),
+			})
+		case codes.DeadlineExceeded:
+			return render(c, http.StatusGone, pageResetExpired, nil)
+		case codes.NotFound:
+			return render(c, http.StatusBadRequest, pageResetInvalid, nil)
+		default:
+			log.Error().Err(err).Msg("Failed to reset password")
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
+}
+
+// setTokenHeaders keeps single-use tokens out of caches and referrers
+func setTokenHeaders(c echo.Context) {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Referrer-Policy", "no-referrer")
+}
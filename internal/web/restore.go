@@ -0,0 +1,68 @@
+package web
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golgoth31/aliasme/internal/validation"
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Account restore pages
+const (
+	pageRestoreForm    = "restore_form"
+	pageRestoreSuccess = "restore_success"
+	pageRestoreExpired = "restore_expired"
+	pageRestoreInvalid = "restore_invalid"
+)
+
+// AccountRestorer restores deleted accounts from their restore token
+type AccountRestorer interface {
+	RestoreUser(ctx context.Context, req *aliasme.RestoreUserRequest) (*aliasme.User, error)
+}
+
+// RestoreFormHandler serves the confirmation page of the links sent when an
+// account is deleted. The account is only restored once confirmed, so link
+// scanners cannot restore it.
+func RestoreFormHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setTokenHeaders(c)
+
+		token := c.QueryParam("token")
+		if token == "" {
+			return render(c, http.StatusBadRequest, pageRestoreInvalid, nil)
+		}
+
+		return render(c, http.StatusOK, pageRestoreForm, map[string]string{"Token": token})
+	}
+}
+
+// RestoreHandler restores the account of the confirmed restore form
+func RestoreHandler(restorer AccountRestorer) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setTokenHeaders(c)
+
+		req := &aliasme.RestoreUserRequest{Token: c.FormValue("token")}
+		// The pages are not served through the validating interceptor
+		if err := validation.Validate(req); err != nil {
+			return render(c, http.StatusBadRequest, pageRestoreInvalid, nil)
+		}
+
+		user, err := restorer.RestoreUser(c.Request().Context(), req)
+		switch status.Code(err) {
+		case codes.OK:
+			return render(c, http.StatusOK, pageRestoreSuccess, map[string]string{"Username": user.Username})
+		case codes.DeadlineExceeded:
+			return render(c, http.StatusGone, pageRestoreExpired, nil)
+		case codes.InvalidArgument, codes.NotFound:
+			return render(c, http.StatusBadRequest, pageRestoreInvalid, nil)
+		default:
+			log.Error().Err(err).Msg("Failed to restore account")
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"html/template"
+	"net/http"
+
+	aliasme "github.com/golgoth31/aliasme/pkg/proto"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Verification pages
+const (
+	pageVerifySuccess = "verify_success"
+	pageVerifyExpired = "verify_expired"
+	pageVerifyInvalid = "verify_invalid"
+)
+
+// pages holds each page parsed with the shared layout
+var pages = parsePages(
+	pageVerifySuccess, pageVerifyExpired, pageVerifyInvalid,
+	pageResetForm, pageResetSuccess, pageResetExpired, pageResetInvalid,
+	pageDeviceCode, pageDeviceForm, pageDeviceApproved, pageDeviceDenied,
+	pageRestoreForm, pageRestoreSuccess, pageRestoreExpired, pageRestoreInvalid,
+)
+
+// EmailVerifier verifies email addresses from their token
+type EmailVerifier interface {
+	VerifyEmail(ctx context.Context, req *aliasme.VerifyEmailRequest) (*aliasme.Email, error)
+}
+
+// VerifyHandler serves the landing page of the links sent in verification
+// emails
+func VerifyHandler(verifier EmailVerifier) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setTokenHeaders(c)
+
+		email, err := verifier.VerifyEmail(c.Request().Context(), &aliasme.VerifyEmailRequest{
+			Token: c.QueryParam("token"),
+		})
+		switch status.Code(err) {
+		case codes.OK:
+			return render(c, http.StatusOK, pageVerifySuccess, map[string]string{"Address": email.Address})
+		case codes.DeadlineExceeded:
+			return render(c, http.StatusGone, pageVerifyExpired, nil)
+		case codes.InvalidArgument, codes.NotFound:
+			return render(c, http.StatusBadRequest, pageVerifyInvalid, nil)
+		default:
+			log.Error().Err(err).Msg("Failed to verify email")
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
+}
+
+// render writes the page as the response
+func render(c echo.Context, code int, page string, data any) error {
+	var buf bytes.Buffer
+	if err := pages[page].ExecuteTemplate(&buf, "layout.html", data); err != nil {
+		log.Error().Err(err).Str("page", page).Msg("Failed to render page")
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.HTMLBlob(code, buf.Bytes())
+}
+
+// parsePages parses the embedded pages, panicking on invalid templates as
+// they are part of the binary
+func parsePages(names ...string) map[string]*template.Template {
+	parsed := make(map[string]*template.Template, len(names))
+	for _, name := range names {
+		parsed[name] = template.Must(template.ParseFS(templatesFS, "templates/layout.html", "templates/"+name+".html"))
+	}
+
+	return parsed
+}
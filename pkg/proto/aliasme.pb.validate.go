@@ -35,104 +35,85 @@ var (
 	_ = sort.Sort
 )
 
-// Validate checks the field values on User with the rules defined in the proto
-// definition for this message. If any rules are violated, the first error
-// encountered is returned, or nil if there are no violations.
-func (m *User) Validate() error {
+// Validate checks the field values on LoginRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LoginRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on User with the rules defined in the
-// proto definition for this message. If any rules are violated, the result is
-// a list of violation errors wrapped in UserMultiError, or nil if none found.
-func (m *User) ValidateAll() error {
+// ValidateAll checks the field values on LoginRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LoginRequestMultiError, or
+// nil if none found.
+func (m *LoginRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *User) validate(all bool) error {
+func (m *LoginRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
-
-	// no validation rules for Username
+	if l := utf8.RuneCountInString(m.GetLogin()); l < 1 || l > 254 {
+		err := LoginRequestValidationError{
+			field:  "Login",
+			reason: "value length must be between 1 and 254 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
-	// no validation rules for Email
+	if utf8.RuneCountInString(m.GetPassword()) < 1 {
+		err := LoginRequestValidationError{
+			field:  "Password",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
-	if all {
-		switch v := interface{}(m.GetCreatedAt()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UserValidationError{
-					field:  "CreatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, UserValidationError{
-					field:  "CreatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
+	if len(m.GetPassword()) > 72 {
+		err := LoginRequestValidationError{
+			field:  "Password",
+			reason: "value length must be at most 72 bytes",
 		}
-	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UserValidationError{
-				field:  "CreatedAt",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
+		if !all {
+			return err
 		}
+		errors = append(errors, err)
 	}
 
-	if all {
-		switch v := interface{}(m.GetUpdatedAt()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UserValidationError{
-					field:  "UpdatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, UserValidationError{
-					field:  "UpdatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
+	if utf8.RuneCountInString(m.GetTotpCode()) > 32 {
+		err := LoginRequestValidationError{
+			field:  "TotpCode",
+			reason: "value length must be at most 32 runes",
 		}
-	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UserValidationError{
-				field:  "UpdatedAt",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
+		if !all {
+			return err
 		}
+		errors = append(errors, err)
 	}
 
 	if len(errors) > 0 {
-		return UserMultiError(errors)
+		return LoginRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// UserMultiError is an error wrapping multiple validation errors returned by
-// User.ValidateAll() if the designated constraints aren't met.
-type UserMultiError []error
+// LoginRequestMultiError is an error wrapping multiple validation errors
+// returned by LoginRequest.ValidateAll() if the designated constraints aren't met.
+type LoginRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UserMultiError) Error() string {
+func (m LoginRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -141,11 +122,11 @@ func (m UserMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UserMultiError) AllErrors() []error { return m }
+func (m LoginRequestMultiError) AllErrors() []error { return m }
 
-// UserValidationError is the validation error returned by User.Validate if the
-// designated constraints aren't met.
-type UserValidationError struct {
+// LoginRequestValidationError is the validation error returned by
+// LoginRequest.Validate if the designated constraints aren't met.
+type LoginRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -153,22 +134,22 @@ type UserValidationError struct {
 }
 
 // Field function returns field value.
-func (e UserValidationError) Field() string { return e.field }
+func (e LoginRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UserValidationError) Reason() string { return e.reason }
+func (e LoginRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UserValidationError) Cause() error { return e.cause }
+func (e LoginRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UserValidationError) Key() bool { return e.key }
+func (e LoginRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UserValidationError) ErrorName() string { return "UserValidationError" }
+func (e LoginRequestValidationError) ErrorName() string { return "LoginRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e UserValidationError) Error() string {
+func (e LoginRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -180,14 +161,14 @@ func (e UserValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUser.%s: %s%s",
+		"invalid %sLoginRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UserValidationError{}
+var _ error = LoginRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -195,50 +176,55 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UserValidationError{}
+} = LoginRequestValidationError{}
 
-// Validate checks the field values on CreateUserRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *CreateUserRequest) Validate() error {
+// Validate checks the field values on RefreshRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RefreshRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on CreateUserRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// CreateUserRequestMultiError, or nil if none found.
-func (m *CreateUserRequest) ValidateAll() error {
+// ValidateAll checks the field values on RefreshRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RefreshRequestMultiError,
+// or nil if none found.
+func (m *RefreshRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *CreateUserRequest) validate(all bool) error {
+func (m *RefreshRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Username
-
-	// no validation rules for Email
-
-	// no validation rules for Password
+	if l := utf8.RuneCountInString(m.GetRefreshToken()); l < 1 || l > 256 {
+		err := RefreshRequestValidationError{
+			field:  "RefreshToken",
+			reason: "value length must be between 1 and 256 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return CreateUserRequestMultiError(errors)
+		return RefreshRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// CreateUserRequestMultiError is an error wrapping multiple validation errors
-// returned by CreateUserRequest.ValidateAll() if the designated constraints
+// RefreshRequestMultiError is an error wrapping multiple validation errors
+// returned by RefreshRequest.ValidateAll() if the designated constraints
 // aren't met.
-type CreateUserRequestMultiError []error
+type RefreshRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m CreateUserRequestMultiError) Error() string {
+func (m RefreshRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -247,11 +233,11 @@ func (m CreateUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m CreateUserRequestMultiError) AllErrors() []error { return m }
+func (m RefreshRequestMultiError) AllErrors() []error { return m }
 
-// CreateUserRequestValidationError is the validation error returned by
-// CreateUserRequest.Validate if the designated constraints aren't met.
-type CreateUserRequestValidationError struct {
+// RefreshRequestValidationError is the validation error returned by
+// RefreshRequest.Validate if the designated constraints aren't met.
+type RefreshRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -259,24 +245,22 @@ type CreateUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e CreateUserRequestValidationError) Field() string { return e.field }
+func (e RefreshRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CreateUserRequestValidationError) Reason() string { return e.reason }
+func (e RefreshRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CreateUserRequestValidationError) Cause() error { return e.cause }
+func (e RefreshRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CreateUserRequestValidationError) Key() bool { return e.key }
+func (e RefreshRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CreateUserRequestValidationError) ErrorName() string {
-	return "CreateUserRequestValidationError"
-}
+func (e RefreshRequestValidationError) ErrorName() string { return "RefreshRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e CreateUserRequestValidationError) Error() string {
+func (e RefreshRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -288,14 +272,14 @@ func (e CreateUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCreateUserRequest.%s: %s%s",
+		"invalid %sRefreshRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CreateUserRequestValidationError{}
+var _ error = RefreshRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -303,46 +287,108 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CreateUserRequestValidationError{}
+} = RefreshRequestValidationError{}
 
-// Validate checks the field values on GetUserRequest with the rules defined in
+// Validate checks the field values on TokenResponse with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *GetUserRequest) Validate() error {
+func (m *TokenResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetUserRequest with the rules defined
+// ValidateAll checks the field values on TokenResponse with the rules defined
 // in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in GetUserRequestMultiError,
-// or nil if none found.
-func (m *GetUserRequest) ValidateAll() error {
+// result is a list of violation errors wrapped in TokenResponseMultiError, or
+// nil if none found.
+func (m *TokenResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetUserRequest) validate(all bool) error {
+func (m *TokenResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
+	// no validation rules for AccessToken
+
+	if all {
+		switch v := interface{}(m.GetAccessTokenExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TokenResponseValidationError{
+					field:  "AccessTokenExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TokenResponseValidationError{
+					field:  "AccessTokenExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAccessTokenExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TokenResponseValidationError{
+				field:  "AccessTokenExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for RefreshToken
+
+	if all {
+		switch v := interface{}(m.GetRefreshTokenExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TokenResponseValidationError{
+					field:  "RefreshTokenExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TokenResponseValidationError{
+					field:  "RefreshTokenExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRefreshTokenExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TokenResponseValidationError{
+				field:  "RefreshTokenExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for TokenType
 
 	if len(errors) > 0 {
-		return GetUserRequestMultiError(errors)
+		return TokenResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetUserRequestMultiError is an error wrapping multiple validation errors
-// returned by GetUserRequest.ValidateAll() if the designated constraints
+// TokenResponseMultiError is an error wrapping multiple validation errors
+// returned by TokenResponse.ValidateAll() if the designated constraints
 // aren't met.
-type GetUserRequestMultiError []error
+type TokenResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetUserRequestMultiError) Error() string {
+func (m TokenResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -351,11 +397,11 @@ func (m GetUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetUserRequestMultiError) AllErrors() []error { return m }
+func (m TokenResponseMultiError) AllErrors() []error { return m }
 
-// GetUserRequestValidationError is the validation error returned by
-// GetUserRequest.Validate if the designated constraints aren't met.
-type GetUserRequestValidationError struct {
+// TokenResponseValidationError is the validation error returned by
+// TokenResponse.Validate if the designated constraints aren't met.
+type TokenResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -363,22 +409,22 @@ type GetUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetUserRequestValidationError) Field() string { return e.field }
+func (e TokenResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetUserRequestValidationError) Reason() string { return e.reason }
+func (e TokenResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetUserRequestValidationError) Cause() error { return e.cause }
+func (e TokenResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetUserRequestValidationError) Key() bool { return e.key }
+func (e TokenResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetUserRequestValidationError) ErrorName() string { return "GetUserRequestValidationError" }
+func (e TokenResponseValidationError) ErrorName() string { return "TokenResponseValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetUserRequestValidationError) Error() string {
+func (e TokenResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -390,14 +436,14 @@ func (e GetUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetUserRequest.%s: %s%s",
+		"invalid %sTokenResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetUserRequestValidationError{}
+var _ error = TokenResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -405,50 +451,55 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetUserRequestValidationError{}
+} = TokenResponseValidationError{}
 
-// Validate checks the field values on UpdateUserRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *UpdateUserRequest) Validate() error {
+// Validate checks the field values on LogoutRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LogoutRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateUserRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// UpdateUserRequestMultiError, or nil if none found.
-func (m *UpdateUserRequest) ValidateAll() error {
+// ValidateAll checks the field values on LogoutRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LogoutRequestMultiError, or
+// nil if none found.
+func (m *LogoutRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateUserRequest) validate(all bool) error {
+func (m *LogoutRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
-
-	// no validation rules for Username
-
-	// no validation rules for Email
+	if l := utf8.RuneCountInString(m.GetRefreshToken()); l < 1 || l > 256 {
+		err := LogoutRequestValidationError{
+			field:  "RefreshToken",
+			reason: "value length must be between 1 and 256 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return UpdateUserRequestMultiError(errors)
+		return LogoutRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateUserRequestMultiError is an error wrapping multiple validation errors
-// returned by UpdateUserRequest.ValidateAll() if the designated constraints
+// LogoutRequestMultiError is an error wrapping multiple validation errors
+// returned by LogoutRequest.ValidateAll() if the designated constraints
 // aren't met.
-type UpdateUserRequestMultiError []error
+type LogoutRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateUserRequestMultiError) Error() string {
+func (m LogoutRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -457,11 +508,11 @@ func (m UpdateUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateUserRequestMultiError) AllErrors() []error { return m }
+func (m LogoutRequestMultiError) AllErrors() []error { return m }
 
-// UpdateUserRequestValidationError is the validation error returned by
-// UpdateUserRequest.Validate if the designated constraints aren't met.
-type UpdateUserRequestValidationError struct {
+// LogoutRequestValidationError is the validation error returned by
+// LogoutRequest.Validate if the designated constraints aren't met.
+type LogoutRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -469,24 +520,22 @@ type UpdateUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateUserRequestValidationError) Field() string { return e.field }
+func (e LogoutRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateUserRequestValidationError) Reason() string { return e.reason }
+func (e LogoutRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateUserRequestValidationError) Cause() error { return e.cause }
+func (e LogoutRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateUserRequestValidationError) Key() bool { return e.key }
+func (e LogoutRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateUserRequestValidationError) ErrorName() string {
-	return "UpdateUserRequestValidationError"
-}
+func (e LogoutRequestValidationError) ErrorName() string { return "LogoutRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e UpdateUserRequestValidationError) Error() string {
+func (e LogoutRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -498,14 +547,14 @@ func (e UpdateUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateUserRequest.%s: %s%s",
+		"invalid %sLogoutRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateUserRequestValidationError{}
+var _ error = LogoutRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -513,46 +562,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateUserRequestValidationError{}
+} = LogoutRequestValidationError{}
 
-// Validate checks the field values on DeleteUserRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *DeleteUserRequest) Validate() error {
+// Validate checks the field values on LogoutResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LogoutResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on DeleteUserRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// DeleteUserRequestMultiError, or nil if none found.
-func (m *DeleteUserRequest) ValidateAll() error {
+// ValidateAll checks the field values on LogoutResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LogoutResponseMultiError,
+// or nil if none found.
+func (m *LogoutResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *DeleteUserRequest) validate(all bool) error {
+func (m *LogoutResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
+	// no validation rules for Success
 
 	if len(errors) > 0 {
-		return DeleteUserRequestMultiError(errors)
+		return LogoutResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// DeleteUserRequestMultiError is an error wrapping multiple validation errors
-// returned by DeleteUserRequest.ValidateAll() if the designated constraints
+// LogoutResponseMultiError is an error wrapping multiple validation errors
+// returned by LogoutResponse.ValidateAll() if the designated constraints
 // aren't met.
-type DeleteUserRequestMultiError []error
+type LogoutResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m DeleteUserRequestMultiError) Error() string {
+func (m LogoutResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -561,11 +610,11 @@ func (m DeleteUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m DeleteUserRequestMultiError) AllErrors() []error { return m }
+func (m LogoutResponseMultiError) AllErrors() []error { return m }
 
-// DeleteUserRequestValidationError is the validation error returned by
-// DeleteUserRequest.Validate if the designated constraints aren't met.
-type DeleteUserRequestValidationError struct {
+// LogoutResponseValidationError is the validation error returned by
+// LogoutResponse.Validate if the designated constraints aren't met.
+type LogoutResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -573,24 +622,22 @@ type DeleteUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e DeleteUserRequestValidationError) Field() string { return e.field }
+func (e LogoutResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e DeleteUserRequestValidationError) Reason() string { return e.reason }
+func (e LogoutResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e DeleteUserRequestValidationError) Cause() error { return e.cause }
+func (e LogoutResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e DeleteUserRequestValidationError) Key() bool { return e.key }
+func (e LogoutResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e DeleteUserRequestValidationError) ErrorName() string {
-	return "DeleteUserRequestValidationError"
-}
+func (e LogoutResponseValidationError) ErrorName() string { return "LogoutResponseValidationError" }
 
 // Error satisfies the builtin error interface
-func (e DeleteUserRequestValidationError) Error() string {
+func (e LogoutResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -602,14 +649,14 @@ func (e DeleteUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sDeleteUserRequest.%s: %s%s",
+		"invalid %sLogoutResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = DeleteUserRequestValidationError{}
+var _ error = LogoutResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -617,46 +664,68 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = DeleteUserRequestValidationError{}
+} = LogoutResponseValidationError{}
 
-// Validate checks the field values on DeleteUserResponse with the rules
+// Validate checks the field values on ChangePasswordRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *DeleteUserResponse) Validate() error {
+func (m *ChangePasswordRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on DeleteUserResponse with the rules
+// ValidateAll checks the field values on ChangePasswordRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// DeleteUserResponseMultiError, or nil if none found.
-func (m *DeleteUserResponse) ValidateAll() error {
+// ChangePasswordRequestMultiError, or nil if none found.
+func (m *ChangePasswordRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *DeleteUserResponse) validate(all bool) error {
+func (m *ChangePasswordRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Success
+	if utf8.RuneCountInString(m.GetCurrentPassword()) < 1 {
+		err := ChangePasswordRequestValidationError{
+			field:  "CurrentPassword",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetCurrentPassword()) > 72 {
+		err := ChangePasswordRequestValidationError{
+			field:  "CurrentPassword",
+			reason: "value length must be at most 72 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for NewPassword
 
 	if len(errors) > 0 {
-		return DeleteUserResponseMultiError(errors)
+		return ChangePasswordRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// DeleteUserResponseMultiError is an error wrapping multiple validation errors
-// returned by DeleteUserResponse.ValidateAll() if the designated constraints
-// aren't met.
-type DeleteUserResponseMultiError []error
+// ChangePasswordRequestMultiError is an error wrapping multiple validation
+// errors returned by ChangePasswordRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ChangePasswordRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m DeleteUserResponseMultiError) Error() string {
+func (m ChangePasswordRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -665,11 +734,11 @@ func (m DeleteUserResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m DeleteUserResponseMultiError) AllErrors() []error { return m }
+func (m ChangePasswordRequestMultiError) AllErrors() []error { return m }
 
-// DeleteUserResponseValidationError is the validation error returned by
-// DeleteUserResponse.Validate if the designated constraints aren't met.
-type DeleteUserResponseValidationError struct {
+// ChangePasswordRequestValidationError is the validation error returned by
+// ChangePasswordRequest.Validate if the designated constraints aren't met.
+type ChangePasswordRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -677,24 +746,24 @@ type DeleteUserResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e DeleteUserResponseValidationError) Field() string { return e.field }
+func (e ChangePasswordRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e DeleteUserResponseValidationError) Reason() string { return e.reason }
+func (e ChangePasswordRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e DeleteUserResponseValidationError) Cause() error { return e.cause }
+func (e ChangePasswordRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e DeleteUserResponseValidationError) Key() bool { return e.key }
+func (e ChangePasswordRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e DeleteUserResponseValidationError) ErrorName() string {
-	return "DeleteUserResponseValidationError"
+func (e ChangePasswordRequestValidationError) ErrorName() string {
+	return "ChangePasswordRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e DeleteUserResponseValidationError) Error() string {
+func (e ChangePasswordRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -706,14 +775,14 @@ func (e DeleteUserResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sDeleteUserResponse.%s: %s%s",
+		"invalid %sChangePasswordRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = DeleteUserResponseValidationError{}
+var _ error = ChangePasswordRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -721,46 +790,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = DeleteUserResponseValidationError{}
+} = ChangePasswordRequestValidationError{}
 
-// Validate checks the field values on GetUserByEmailRequest with the rules
+// Validate checks the field values on ChangePasswordResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetUserByEmailRequest) Validate() error {
+func (m *ChangePasswordResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetUserByEmailRequest with the rules
+// ValidateAll checks the field values on ChangePasswordResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetUserByEmailRequestMultiError, or nil if none found.
-func (m *GetUserByEmailRequest) ValidateAll() error {
+// ChangePasswordResponseMultiError, or nil if none found.
+func (m *ChangePasswordResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetUserByEmailRequest) validate(all bool) error {
+func (m *ChangePasswordResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Email
+	// no validation rules for Success
 
 	if len(errors) > 0 {
-		return GetUserByEmailRequestMultiError(errors)
+		return ChangePasswordResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetUserByEmailRequestMultiError is an error wrapping multiple validation
-// errors returned by GetUserByEmailRequest.ValidateAll() if the designated
+// ChangePasswordResponseMultiError is an error wrapping multiple validation
+// errors returned by ChangePasswordResponse.ValidateAll() if the designated
 // constraints aren't met.
-type GetUserByEmailRequestMultiError []error
+type ChangePasswordResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetUserByEmailRequestMultiError) Error() string {
+func (m ChangePasswordResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -769,11 +838,11 @@ func (m GetUserByEmailRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetUserByEmailRequestMultiError) AllErrors() []error { return m }
+func (m ChangePasswordResponseMultiError) AllErrors() []error { return m }
 
-// GetUserByEmailRequestValidationError is the validation error returned by
-// GetUserByEmailRequest.Validate if the designated constraints aren't met.
-type GetUserByEmailRequestValidationError struct {
+// ChangePasswordResponseValidationError is the validation error returned by
+// ChangePasswordResponse.Validate if the designated constraints aren't met.
+type ChangePasswordResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -781,24 +850,24 @@ type GetUserByEmailRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetUserByEmailRequestValidationError) Field() string { return e.field }
+func (e ChangePasswordResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetUserByEmailRequestValidationError) Reason() string { return e.reason }
+func (e ChangePasswordResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetUserByEmailRequestValidationError) Cause() error { return e.cause }
+func (e ChangePasswordResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetUserByEmailRequestValidationError) Key() bool { return e.key }
+func (e ChangePasswordResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetUserByEmailRequestValidationError) ErrorName() string {
-	return "GetUserByEmailRequestValidationError"
+func (e ChangePasswordResponseValidationError) ErrorName() string {
+	return "ChangePasswordResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetUserByEmailRequestValidationError) Error() string {
+func (e ChangePasswordResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -810,14 +879,14 @@ func (e GetUserByEmailRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetUserByEmailRequest.%s: %s%s",
+		"invalid %sChangePasswordResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetUserByEmailRequestValidationError{}
+var _ error = ChangePasswordResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -825,46 +894,117 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetUserByEmailRequestValidationError{}
+} = ChangePasswordResponseValidationError{}
 
-// Validate checks the field values on GetUserByEmailResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on RequestPasswordResetRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetUserByEmailResponse) Validate() error {
+func (m *RequestPasswordResetRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetUserByEmailResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on RequestPasswordResetRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetUserByEmailResponseMultiError, or nil if none found.
-func (m *GetUserByEmailResponse) ValidateAll() error {
+// RequestPasswordResetRequestMultiError, or nil if none found.
+func (m *RequestPasswordResetRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetUserByEmailResponse) validate(all bool) error {
+func (m *RequestPasswordResetRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for UserId
+	if utf8.RuneCountInString(m.GetEmail()) > 254 {
+		err := RequestPasswordResetRequestValidationError{
+			field:  "Email",
+			reason: "value length must be at most 254 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if err := m._validateEmail(m.GetEmail()); err != nil {
+		err = RequestPasswordResetRequestValidationError{
+			field:  "Email",
+			reason: "value must be a valid email address",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return GetUserByEmailResponseMultiError(errors)
+		return RequestPasswordResetRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetUserByEmailResponseMultiError is an error wrapping multiple validation
-// errors returned by GetUserByEmailResponse.ValidateAll() if the designated
-// constraints aren't met.
-type GetUserByEmailResponseMultiError []error
+func (m *RequestPasswordResetRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *RequestPasswordResetRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// RequestPasswordResetRequestMultiError is an error wrapping multiple
+// validation errors returned by RequestPasswordResetRequest.ValidateAll() if
+// the designated constraints aren't met.
+type RequestPasswordResetRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetUserByEmailResponseMultiError) Error() string {
+func (m RequestPasswordResetRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -873,11 +1013,12 @@ func (m GetUserByEmailResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetUserByEmailResponseMultiError) AllErrors() []error { return m }
+func (m RequestPasswordResetRequestMultiError) AllErrors() []error { return m }
 
-// GetUserByEmailResponseValidationError is the validation error returned by
-// GetUserByEmailResponse.Validate if the designated constraints aren't met.
-type GetUserByEmailResponseValidationError struct {
+// RequestPasswordResetRequestValidationError is the validation error returned
+// by RequestPasswordResetRequest.Validate if the designated constraints
+// aren't met.
+type RequestPasswordResetRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -885,24 +1026,24 @@ type GetUserByEmailResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetUserByEmailResponseValidationError) Field() string { return e.field }
+func (e RequestPasswordResetRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetUserByEmailResponseValidationError) Reason() string { return e.reason }
+func (e RequestPasswordResetRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetUserByEmailResponseValidationError) Cause() error { return e.cause }
+func (e RequestPasswordResetRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetUserByEmailResponseValidationError) Key() bool { return e.key }
+func (e RequestPasswordResetRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetUserByEmailResponseValidationError) ErrorName() string {
-	return "GetUserByEmailResponseValidationError"
+func (e RequestPasswordResetRequestValidationError) ErrorName() string {
+	return "RequestPasswordResetRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetUserByEmailResponseValidationError) Error() string {
+func (e RequestPasswordResetRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -914,14 +1055,14 @@ func (e GetUserByEmailResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetUserByEmailResponse.%s: %s%s",
+		"invalid %sRequestPasswordResetRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetUserByEmailResponseValidationError{}
+var _ error = RequestPasswordResetRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -929,24 +1070,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetUserByEmailResponseValidationError{}
+} = RequestPasswordResetRequestValidationError{}
 
-// Validate checks the field values on ListUsersRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *ListUsersRequest) Validate() error {
+// Validate checks the field values on RequestPasswordResetResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RequestPasswordResetResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListUsersRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on RequestPasswordResetResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ListUsersRequestMultiError, or nil if none found.
-func (m *ListUsersRequest) ValidateAll() error {
+// RequestPasswordResetResponseMultiError, or nil if none found.
+func (m *RequestPasswordResetResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListUsersRequest) validate(all bool) error {
+func (m *RequestPasswordResetResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -954,19 +1095,19 @@ func (m *ListUsersRequest) validate(all bool) error {
 	var errors []error
 
 	if len(errors) > 0 {
-		return ListUsersRequestMultiError(errors)
+		return RequestPasswordResetResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListUsersRequestMultiError is an error wrapping multiple validation errors
-// returned by ListUsersRequest.ValidateAll() if the designated constraints
-// aren't met.
-type ListUsersRequestMultiError []error
+// RequestPasswordResetResponseMultiError is an error wrapping multiple
+// validation errors returned by RequestPasswordResetResponse.ValidateAll() if
+// the designated constraints aren't met.
+type RequestPasswordResetResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListUsersRequestMultiError) Error() string {
+func (m RequestPasswordResetResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -975,11 +1116,12 @@ func (m ListUsersRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListUsersRequestMultiError) AllErrors() []error { return m }
+func (m RequestPasswordResetResponseMultiError) AllErrors() []error { return m }
 
-// ListUsersRequestValidationError is the validation error returned by
-// ListUsersRequest.Validate if the designated constraints aren't met.
-type ListUsersRequestValidationError struct {
+// RequestPasswordResetResponseValidationError is the validation error returned
+// by RequestPasswordResetResponse.Validate if the designated constraints
+// aren't met.
+type RequestPasswordResetResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -987,22 +1129,24 @@ type ListUsersRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListUsersRequestValidationError) Field() string { return e.field }
+func (e RequestPasswordResetResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListUsersRequestValidationError) Reason() string { return e.reason }
+func (e RequestPasswordResetResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListUsersRequestValidationError) Cause() error { return e.cause }
+func (e RequestPasswordResetResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListUsersRequestValidationError) Key() bool { return e.key }
+func (e RequestPasswordResetResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListUsersRequestValidationError) ErrorName() string { return "ListUsersRequestValidationError" }
+func (e RequestPasswordResetResponseValidationError) ErrorName() string {
+	return "RequestPasswordResetResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ListUsersRequestValidationError) Error() string {
+func (e RequestPasswordResetResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1014,14 +1158,14 @@ func (e ListUsersRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListUsersRequest.%s: %s%s",
+		"invalid %sRequestPasswordResetResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListUsersRequestValidationError{}
+var _ error = RequestPasswordResetResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1029,78 +1173,57 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListUsersRequestValidationError{}
+} = RequestPasswordResetResponseValidationError{}
 
-// Validate checks the field values on ListUsersResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *ListUsersResponse) Validate() error {
+// Validate checks the field values on ResetPasswordRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResetPasswordRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListUsersResponse with the rules
+// ValidateAll checks the field values on ResetPasswordRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ListUsersResponseMultiError, or nil if none found.
-func (m *ListUsersResponse) ValidateAll() error {
+// ResetPasswordRequestMultiError, or nil if none found.
+func (m *ResetPasswordRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListUsersResponse) validate(all bool) error {
+func (m *ResetPasswordRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetUsers() {
-		_, _ = idx, item
-
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, ListUsersResponseValidationError{
-						field:  fmt.Sprintf("Users[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, ListUsersResponseValidationError{
-						field:  fmt.Sprintf("Users[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return ListUsersResponseValidationError{
-					field:  fmt.Sprintf("Users[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
+	if l := utf8.RuneCountInString(m.GetToken()); l < 1 || l > 256 {
+		err := ResetPasswordRequestValidationError{
+			field:  "Token",
+			reason: "value length must be between 1 and 256 runes, inclusive",
 		}
-
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
+	// no validation rules for NewPassword
+
 	if len(errors) > 0 {
-		return ListUsersResponseMultiError(errors)
+		return ResetPasswordRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListUsersResponseMultiError is an error wrapping multiple validation errors
-// returned by ListUsersResponse.ValidateAll() if the designated constraints
-// aren't met.
-type ListUsersResponseMultiError []error
+// ResetPasswordRequestMultiError is an error wrapping multiple validation
+// errors returned by ResetPasswordRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ResetPasswordRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListUsersResponseMultiError) Error() string {
+func (m ResetPasswordRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1109,11 +1232,11 @@ func (m ListUsersResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListUsersResponseMultiError) AllErrors() []error { return m }
+func (m ResetPasswordRequestMultiError) AllErrors() []error { return m }
 
-// ListUsersResponseValidationError is the validation error returned by
-// ListUsersResponse.Validate if the designated constraints aren't met.
-type ListUsersResponseValidationError struct {
+// ResetPasswordRequestValidationError is the validation error returned by
+// ResetPasswordRequest.Validate if the designated constraints aren't met.
+type ResetPasswordRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1121,24 +1244,24 @@ type ListUsersResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListUsersResponseValidationError) Field() string { return e.field }
+func (e ResetPasswordRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListUsersResponseValidationError) Reason() string { return e.reason }
+func (e ResetPasswordRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListUsersResponseValidationError) Cause() error { return e.cause }
+func (e ResetPasswordRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListUsersResponseValidationError) Key() bool { return e.key }
+func (e ResetPasswordRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListUsersResponseValidationError) ErrorName() string {
-	return "ListUsersResponseValidationError"
+func (e ResetPasswordRequestValidationError) ErrorName() string {
+	return "ResetPasswordRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListUsersResponseValidationError) Error() string {
+func (e ResetPasswordRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1150,14 +1273,14 @@ func (e ListUsersResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListUsersResponse.%s: %s%s",
+		"invalid %sResetPasswordRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListUsersResponseValidationError{}
+var _ error = ResetPasswordRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1165,108 +1288,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListUsersResponseValidationError{}
+} = ResetPasswordRequestValidationError{}
 
-// Validate checks the field values on Email with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *Email) Validate() error {
+// Validate checks the field values on ResetPasswordResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResetPasswordResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on Email with the rules defined in the
-// proto definition for this message. If any rules are violated, the result is
-// a list of violation errors wrapped in EmailMultiError, or nil if none found.
-func (m *Email) ValidateAll() error {
-	return m.validate(true)
+// ValidateAll checks the field values on ResetPasswordResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResetPasswordResponseMultiError, or nil if none found.
+func (m *ResetPasswordResponse) ValidateAll() error {
+	return m.validate(true)
 }
 
-func (m *Email) validate(all bool) error {
+func (m *ResetPasswordResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
-
-	// no validation rules for UserId
-
-	// no validation rules for Address
-
-	// no validation rules for Verified
-
-	if all {
-		switch v := interface{}(m.GetCreatedAt()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, EmailValidationError{
-					field:  "CreatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, EmailValidationError{
-					field:  "CreatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return EmailValidationError{
-				field:  "CreatedAt",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
-
-	if all {
-		switch v := interface{}(m.GetUpdatedAt()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, EmailValidationError{
-					field:  "UpdatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, EmailValidationError{
-					field:  "UpdatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return EmailValidationError{
-				field:  "UpdatedAt",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Success
 
 	if len(errors) > 0 {
-		return EmailMultiError(errors)
+		return ResetPasswordResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// EmailMultiError is an error wrapping multiple validation errors returned by
-// Email.ValidateAll() if the designated constraints aren't met.
-type EmailMultiError []error
+// ResetPasswordResponseMultiError is an error wrapping multiple validation
+// errors returned by ResetPasswordResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ResetPasswordResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m EmailMultiError) Error() string {
+func (m ResetPasswordResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1275,11 +1336,11 @@ func (m EmailMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m EmailMultiError) AllErrors() []error { return m }
+func (m ResetPasswordResponseMultiError) AllErrors() []error { return m }
 
-// EmailValidationError is the validation error returned by Email.Validate if
-// the designated constraints aren't met.
-type EmailValidationError struct {
+// ResetPasswordResponseValidationError is the validation error returned by
+// ResetPasswordResponse.Validate if the designated constraints aren't met.
+type ResetPasswordResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1287,22 +1348,24 @@ type EmailValidationError struct {
 }
 
 // Field function returns field value.
-func (e EmailValidationError) Field() string { return e.field }
+func (e ResetPasswordResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e EmailValidationError) Reason() string { return e.reason }
+func (e ResetPasswordResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e EmailValidationError) Cause() error { return e.cause }
+func (e ResetPasswordResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e EmailValidationError) Key() bool { return e.key }
+func (e ResetPasswordResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e EmailValidationError) ErrorName() string { return "EmailValidationError" }
+func (e ResetPasswordResponseValidationError) ErrorName() string {
+	return "ResetPasswordResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e EmailValidationError) Error() string {
+func (e ResetPasswordResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1314,14 +1377,14 @@ func (e EmailValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sEmail.%s: %s%s",
+		"invalid %sResetPasswordResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = EmailValidationError{}
+var _ error = ResetPasswordResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1329,48 +1392,44 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = EmailValidationError{}
+} = ResetPasswordResponseValidationError{}
 
-// Validate checks the field values on RegisterEmailRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RegisterEmailRequest) Validate() error {
+// Validate checks the field values on EnrollTOTPRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *EnrollTOTPRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RegisterEmailRequest with the rules
+// ValidateAll checks the field values on EnrollTOTPRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// RegisterEmailRequestMultiError, or nil if none found.
-func (m *RegisterEmailRequest) ValidateAll() error {
+// EnrollTOTPRequestMultiError, or nil if none found.
+func (m *EnrollTOTPRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RegisterEmailRequest) validate(all bool) error {
+func (m *EnrollTOTPRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for UserId
-
-	// no validation rules for EmailAddress
-
 	if len(errors) > 0 {
-		return RegisterEmailRequestMultiError(errors)
+		return EnrollTOTPRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// RegisterEmailRequestMultiError is an error wrapping multiple validation
-// errors returned by RegisterEmailRequest.ValidateAll() if the designated
-// constraints aren't met.
-type RegisterEmailRequestMultiError []error
+// EnrollTOTPRequestMultiError is an error wrapping multiple validation errors
+// returned by EnrollTOTPRequest.ValidateAll() if the designated constraints
+// aren't met.
+type EnrollTOTPRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RegisterEmailRequestMultiError) Error() string {
+func (m EnrollTOTPRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1379,11 +1438,11 @@ func (m RegisterEmailRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RegisterEmailRequestMultiError) AllErrors() []error { return m }
+func (m EnrollTOTPRequestMultiError) AllErrors() []error { return m }
 
-// RegisterEmailRequestValidationError is the validation error returned by
-// RegisterEmailRequest.Validate if the designated constraints aren't met.
-type RegisterEmailRequestValidationError struct {
+// EnrollTOTPRequestValidationError is the validation error returned by
+// EnrollTOTPRequest.Validate if the designated constraints aren't met.
+type EnrollTOTPRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1391,24 +1450,24 @@ type RegisterEmailRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e RegisterEmailRequestValidationError) Field() string { return e.field }
+func (e EnrollTOTPRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RegisterEmailRequestValidationError) Reason() string { return e.reason }
+func (e EnrollTOTPRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RegisterEmailRequestValidationError) Cause() error { return e.cause }
+func (e EnrollTOTPRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RegisterEmailRequestValidationError) Key() bool { return e.key }
+func (e EnrollTOTPRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RegisterEmailRequestValidationError) ErrorName() string {
-	return "RegisterEmailRequestValidationError"
+func (e EnrollTOTPRequestValidationError) ErrorName() string {
+	return "EnrollTOTPRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e RegisterEmailRequestValidationError) Error() string {
+func (e EnrollTOTPRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1420,14 +1479,14 @@ func (e RegisterEmailRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRegisterEmailRequest.%s: %s%s",
+		"invalid %sEnrollTOTPRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RegisterEmailRequestValidationError{}
+var _ error = EnrollTOTPRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1435,46 +1494,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RegisterEmailRequestValidationError{}
+} = EnrollTOTPRequestValidationError{}
 
-// Validate checks the field values on VerifyEmailRequest with the rules
+// Validate checks the field values on EnrollTOTPResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *VerifyEmailRequest) Validate() error {
+func (m *EnrollTOTPResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on VerifyEmailRequest with the rules
+// ValidateAll checks the field values on EnrollTOTPResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// VerifyEmailRequestMultiError, or nil if none found.
-func (m *VerifyEmailRequest) ValidateAll() error {
+// EnrollTOTPResponseMultiError, or nil if none found.
+func (m *EnrollTOTPResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *VerifyEmailRequest) validate(all bool) error {
+func (m *EnrollTOTPResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Token
+	// no validation rules for Secret
+
+	// no validation rules for OtpauthUri
 
 	if len(errors) > 0 {
-		return VerifyEmailRequestMultiError(errors)
+		return EnrollTOTPResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// VerifyEmailRequestMultiError is an error wrapping multiple validation errors
-// returned by VerifyEmailRequest.ValidateAll() if the designated constraints
+// EnrollTOTPResponseMultiError is an error wrapping multiple validation errors
+// returned by EnrollTOTPResponse.ValidateAll() if the designated constraints
 // aren't met.
-type VerifyEmailRequestMultiError []error
+type EnrollTOTPResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m VerifyEmailRequestMultiError) Error() string {
+func (m EnrollTOTPResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1483,11 +1544,11 @@ func (m VerifyEmailRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m VerifyEmailRequestMultiError) AllErrors() []error { return m }
+func (m EnrollTOTPResponseMultiError) AllErrors() []error { return m }
 
-// VerifyEmailRequestValidationError is the validation error returned by
-// VerifyEmailRequest.Validate if the designated constraints aren't met.
-type VerifyEmailRequestValidationError struct {
+// EnrollTOTPResponseValidationError is the validation error returned by
+// EnrollTOTPResponse.Validate if the designated constraints aren't met.
+type EnrollTOTPResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1495,24 +1556,24 @@ type VerifyEmailRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e VerifyEmailRequestValidationError) Field() string { return e.field }
+func (e EnrollTOTPResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e VerifyEmailRequestValidationError) Reason() string { return e.reason }
+func (e EnrollTOTPResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e VerifyEmailRequestValidationError) Cause() error { return e.cause }
+func (e EnrollTOTPResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e VerifyEmailRequestValidationError) Key() bool { return e.key }
+func (e EnrollTOTPResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e VerifyEmailRequestValidationError) ErrorName() string {
-	return "VerifyEmailRequestValidationError"
+func (e EnrollTOTPResponseValidationError) ErrorName() string {
+	return "EnrollTOTPResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e VerifyEmailRequestValidationError) Error() string {
+func (e EnrollTOTPResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1524,14 +1585,14 @@ func (e VerifyEmailRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sVerifyEmailRequest.%s: %s%s",
+		"invalid %sEnrollTOTPResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = VerifyEmailRequestValidationError{}
+var _ error = EnrollTOTPResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1539,108 +1600,55 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = VerifyEmailRequestValidationError{}
+} = EnrollTOTPResponseValidationError{}
 
-// Validate checks the field values on Alias with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *Alias) Validate() error {
+// Validate checks the field values on ConfirmTOTPRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConfirmTOTPRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on Alias with the rules defined in the
-// proto definition for this message. If any rules are violated, the result is
-// a list of violation errors wrapped in AliasMultiError, or nil if none found.
-func (m *Alias) ValidateAll() error {
+// ValidateAll checks the field values on ConfirmTOTPRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfirmTOTPRequestMultiError, or nil if none found.
+func (m *ConfirmTOTPRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *Alias) validate(all bool) error {
+func (m *ConfirmTOTPRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
-
-	// no validation rules for UserId
-
-	// no validation rules for EmailId
-
-	// no validation rules for AliasAddress
-
-	if all {
-		switch v := interface{}(m.GetCreatedAt()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AliasValidationError{
-					field:  "CreatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, AliasValidationError{
-					field:  "CreatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return AliasValidationError{
-				field:  "CreatedAt",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
-
-	if all {
-		switch v := interface{}(m.GetUpdatedAt()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AliasValidationError{
-					field:  "UpdatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, AliasValidationError{
-					field:  "UpdatedAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
+	if l := utf8.RuneCountInString(m.GetCode()); l < 1 || l > 32 {
+		err := ConfirmTOTPRequestValidationError{
+			field:  "Code",
+			reason: "value length must be between 1 and 32 runes, inclusive",
 		}
-	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return AliasValidationError{
-				field:  "UpdatedAt",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
+		if !all {
+			return err
 		}
+		errors = append(errors, err)
 	}
 
 	if len(errors) > 0 {
-		return AliasMultiError(errors)
+		return ConfirmTOTPRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// AliasMultiError is an error wrapping multiple validation errors returned by
-// Alias.ValidateAll() if the designated constraints aren't met.
-type AliasMultiError []error
+// ConfirmTOTPRequestMultiError is an error wrapping multiple validation errors
+// returned by ConfirmTOTPRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ConfirmTOTPRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m AliasMultiError) Error() string {
+func (m ConfirmTOTPRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1649,11 +1657,11 @@ func (m AliasMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m AliasMultiError) AllErrors() []error { return m }
+func (m ConfirmTOTPRequestMultiError) AllErrors() []error { return m }
 
-// AliasValidationError is the validation error returned by Alias.Validate if
-// the designated constraints aren't met.
-type AliasValidationError struct {
+// ConfirmTOTPRequestValidationError is the validation error returned by
+// ConfirmTOTPRequest.Validate if the designated constraints aren't met.
+type ConfirmTOTPRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1661,22 +1669,24 @@ type AliasValidationError struct {
 }
 
 // Field function returns field value.
-func (e AliasValidationError) Field() string { return e.field }
+func (e ConfirmTOTPRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e AliasValidationError) Reason() string { return e.reason }
+func (e ConfirmTOTPRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e AliasValidationError) Cause() error { return e.cause }
+func (e ConfirmTOTPRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e AliasValidationError) Key() bool { return e.key }
+func (e ConfirmTOTPRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e AliasValidationError) ErrorName() string { return "AliasValidationError" }
+func (e ConfirmTOTPRequestValidationError) ErrorName() string {
+	return "ConfirmTOTPRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e AliasValidationError) Error() string {
+func (e ConfirmTOTPRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1688,14 +1698,14 @@ func (e AliasValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sAlias.%s: %s%s",
+		"invalid %sConfirmTOTPRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = AliasValidationError{}
+var _ error = ConfirmTOTPRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1703,50 +1713,6481 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = AliasValidationError{}
+} = ConfirmTOTPRequestValidationError{}
 
-// Validate checks the field values on CreateAliasRequest with the rules
+// Validate checks the field values on ConfirmTOTPResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *CreateAliasRequest) Validate() error {
+func (m *ConfirmTOTPResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on CreateAliasRequest with the rules
+// ValidateAll checks the field values on ConfirmTOTPResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// CreateAliasRequestMultiError, or nil if none found.
-func (m *CreateAliasRequest) ValidateAll() error {
+// ConfirmTOTPResponseMultiError, or nil if none found.
+func (m *ConfirmTOTPResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *CreateAliasRequest) validate(all bool) error {
+func (m *ConfirmTOTPResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for UserId
+	if len(errors) > 0 {
+		return ConfirmTOTPResponseMultiError(errors)
+	}
 
-	// no validation rules for EmailId
+	return nil
+}
+
+// ConfirmTOTPResponseMultiError is an error wrapping multiple validation
+// errors returned by ConfirmTOTPResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ConfirmTOTPResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfirmTOTPResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfirmTOTPResponseMultiError) AllErrors() []error { return m }
+
+// ConfirmTOTPResponseValidationError is the validation error returned by
+// ConfirmTOTPResponse.Validate if the designated constraints aren't met.
+type ConfirmTOTPResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfirmTOTPResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfirmTOTPResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfirmTOTPResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfirmTOTPResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfirmTOTPResponseValidationError) ErrorName() string {
+	return "ConfirmTOTPResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ConfirmTOTPResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConfirmTOTPResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConfirmTOTPResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfirmTOTPResponseValidationError{}
+
+// Validate checks the field values on DisableTOTPRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DisableTOTPRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DisableTOTPRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DisableTOTPRequestMultiError, or nil if none found.
+func (m *DisableTOTPRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DisableTOTPRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetCode()); l < 1 || l > 32 {
+		err := DisableTOTPRequestValidationError{
+			field:  "Code",
+			reason: "value length must be between 1 and 32 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DisableTOTPRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DisableTOTPRequestMultiError is an error wrapping multiple validation errors
+// returned by DisableTOTPRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DisableTOTPRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DisableTOTPRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DisableTOTPRequestMultiError) AllErrors() []error { return m }
+
+// DisableTOTPRequestValidationError is the validation error returned by
+// DisableTOTPRequest.Validate if the designated constraints aren't met.
+type DisableTOTPRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DisableTOTPRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DisableTOTPRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DisableTOTPRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DisableTOTPRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DisableTOTPRequestValidationError) ErrorName() string {
+	return "DisableTOTPRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DisableTOTPRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDisableTOTPRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DisableTOTPRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DisableTOTPRequestValidationError{}
+
+// Validate checks the field values on DisableTOTPResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DisableTOTPResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DisableTOTPResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DisableTOTPResponseMultiError, or nil if none found.
+func (m *DisableTOTPResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DisableTOTPResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	if len(errors) > 0 {
+		return DisableTOTPResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DisableTOTPResponseMultiError is an error wrapping multiple validation
+// errors returned by DisableTOTPResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DisableTOTPResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DisableTOTPResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DisableTOTPResponseMultiError) AllErrors() []error { return m }
+
+// DisableTOTPResponseValidationError is the validation error returned by
+// DisableTOTPResponse.Validate if the designated constraints aren't met.
+type DisableTOTPResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DisableTOTPResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DisableTOTPResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DisableTOTPResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DisableTOTPResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DisableTOTPResponseValidationError) ErrorName() string {
+	return "DisableTOTPResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DisableTOTPResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDisableTOTPResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DisableTOTPResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DisableTOTPResponseValidationError{}
+
+// Validate checks the field values on StartDeviceLoginRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *StartDeviceLoginRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StartDeviceLoginRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// StartDeviceLoginRequestMultiError, or nil if none found.
+func (m *StartDeviceLoginRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StartDeviceLoginRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return StartDeviceLoginRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// StartDeviceLoginRequestMultiError is an error wrapping multiple validation
+// errors returned by StartDeviceLoginRequest.ValidateAll() if the designated
+// constraints aren't met.
+type StartDeviceLoginRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StartDeviceLoginRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StartDeviceLoginRequestMultiError) AllErrors() []error { return m }
+
+// StartDeviceLoginRequestValidationError is the validation error returned by
+// StartDeviceLoginRequest.Validate if the designated constraints aren't met.
+type StartDeviceLoginRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StartDeviceLoginRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StartDeviceLoginRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StartDeviceLoginRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StartDeviceLoginRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StartDeviceLoginRequestValidationError) ErrorName() string {
+	return "StartDeviceLoginRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StartDeviceLoginRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStartDeviceLoginRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StartDeviceLoginRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StartDeviceLoginRequestValidationError{}
+
+// Validate checks the field values on StartDeviceLoginResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *StartDeviceLoginResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StartDeviceLoginResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// StartDeviceLoginResponseMultiError, or nil if none found.
+func (m *StartDeviceLoginResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StartDeviceLoginResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for DeviceCode
+
+	// no validation rules for UserCode
+
+	// no validation rules for VerificationUri
+
+	// no validation rules for VerificationUriComplete
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, StartDeviceLoginResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, StartDeviceLoginResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return StartDeviceLoginResponseValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Interval
+
+	if len(errors) > 0 {
+		return StartDeviceLoginResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// StartDeviceLoginResponseMultiError is an error wrapping multiple validation
+// errors returned by StartDeviceLoginResponse.ValidateAll() if the designated
+// constraints aren't met.
+type StartDeviceLoginResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StartDeviceLoginResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StartDeviceLoginResponseMultiError) AllErrors() []error { return m }
+
+// StartDeviceLoginResponseValidationError is the validation error returned by
+// StartDeviceLoginResponse.Validate if the designated constraints aren't met.
+type StartDeviceLoginResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StartDeviceLoginResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StartDeviceLoginResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StartDeviceLoginResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StartDeviceLoginResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StartDeviceLoginResponseValidationError) ErrorName() string {
+	return "StartDeviceLoginResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e StartDeviceLoginResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStartDeviceLoginResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StartDeviceLoginResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StartDeviceLoginResponseValidationError{}
+
+// Validate checks the field values on PollDeviceLoginRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PollDeviceLoginRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PollDeviceLoginRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PollDeviceLoginRequestMultiError, or nil if none found.
+func (m *PollDeviceLoginRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PollDeviceLoginRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetDeviceCode()); l < 1 || l > 256 {
+		err := PollDeviceLoginRequestValidationError{
+			field:  "DeviceCode",
+			reason: "value length must be between 1 and 256 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return PollDeviceLoginRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PollDeviceLoginRequestMultiError is an error wrapping multiple validation
+// errors returned by PollDeviceLoginRequest.ValidateAll() if the designated
+// constraints aren't met.
+type PollDeviceLoginRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PollDeviceLoginRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PollDeviceLoginRequestMultiError) AllErrors() []error { return m }
+
+// PollDeviceLoginRequestValidationError is the validation error returned by
+// PollDeviceLoginRequest.Validate if the designated constraints aren't met.
+type PollDeviceLoginRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PollDeviceLoginRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PollDeviceLoginRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PollDeviceLoginRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PollDeviceLoginRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PollDeviceLoginRequestValidationError) ErrorName() string {
+	return "PollDeviceLoginRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PollDeviceLoginRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPollDeviceLoginRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PollDeviceLoginRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PollDeviceLoginRequestValidationError{}
+
+// Validate checks the field values on PersonalAccessToken with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PersonalAccessToken) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PersonalAccessToken with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PersonalAccessTokenMultiError, or nil if none found.
+func (m *PersonalAccessToken) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PersonalAccessToken) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PersonalAccessTokenValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PersonalAccessTokenValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PersonalAccessTokenValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PersonalAccessTokenValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PersonalAccessTokenValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PersonalAccessTokenValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLastUsedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PersonalAccessTokenValidationError{
+					field:  "LastUsedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PersonalAccessTokenValidationError{
+					field:  "LastUsedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastUsedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PersonalAccessTokenValidationError{
+				field:  "LastUsedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRevokedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PersonalAccessTokenValidationError{
+					field:  "RevokedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PersonalAccessTokenValidationError{
+					field:  "RevokedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRevokedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PersonalAccessTokenValidationError{
+				field:  "RevokedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return PersonalAccessTokenMultiError(errors)
+	}
+
+	return nil
+}
+
+// PersonalAccessTokenMultiError is an error wrapping multiple validation
+// errors returned by PersonalAccessToken.ValidateAll() if the designated
+// constraints aren't met.
+type PersonalAccessTokenMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PersonalAccessTokenMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PersonalAccessTokenMultiError) AllErrors() []error { return m }
+
+// PersonalAccessTokenValidationError is the validation error returned by
+// PersonalAccessToken.Validate if the designated constraints aren't met.
+type PersonalAccessTokenValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PersonalAccessTokenValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PersonalAccessTokenValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PersonalAccessTokenValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PersonalAccessTokenValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PersonalAccessTokenValidationError) ErrorName() string {
+	return "PersonalAccessTokenValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PersonalAccessTokenValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPersonalAccessToken.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PersonalAccessTokenValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PersonalAccessTokenValidationError{}
+
+// Validate checks the field values on CreateTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateTokenRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateTokenRequestMultiError, or nil if none found.
+func (m *CreateTokenRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateTokenRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 64 {
+		err := CreateTokenRequestValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetScopes()) < 1 {
+		err := CreateTokenRequestValidationError{
+			field:  "Scopes",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetScopes() {
+		_, _ = idx, item
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 32 {
+			err := CreateTokenRequestValidationError{
+				field:  fmt.Sprintf("Scopes[%v]", idx),
+				reason: "value length must be between 1 and 32 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateTokenRequestValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateTokenRequestValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateTokenRequestValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateTokenRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateTokenRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateTokenRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateTokenRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateTokenRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateTokenRequestMultiError) AllErrors() []error { return m }
+
+// CreateTokenRequestValidationError is the validation error returned by
+// CreateTokenRequest.Validate if the designated constraints aren't met.
+type CreateTokenRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateTokenRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateTokenRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateTokenRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateTokenRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateTokenRequestValidationError) ErrorName() string {
+	return "CreateTokenRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateTokenRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateTokenRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateTokenRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateTokenRequestValidationError{}
+
+// Validate checks the field values on CreateTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateTokenResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateTokenResponseMultiError, or nil if none found.
+func (m *CreateTokenResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateTokenResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Token
+
+	if all {
+		switch v := interface{}(m.GetInfo()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateTokenResponseValidationError{
+					field:  "Info",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateTokenResponseValidationError{
+					field:  "Info",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetInfo()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateTokenResponseValidationError{
+				field:  "Info",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateTokenResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateTokenResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateTokenResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CreateTokenResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateTokenResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateTokenResponseMultiError) AllErrors() []error { return m }
+
+// CreateTokenResponseValidationError is the validation error returned by
+// CreateTokenResponse.Validate if the designated constraints aren't met.
+type CreateTokenResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateTokenResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateTokenResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateTokenResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateTokenResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateTokenResponseValidationError) ErrorName() string {
+	return "CreateTokenResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateTokenResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateTokenResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateTokenResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateTokenResponseValidationError{}
+
+// Validate checks the field values on ListTokensRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListTokensRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListTokensRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListTokensRequestMultiError, or nil if none found.
+func (m *ListTokensRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListTokensRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListTokensRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListTokensRequestMultiError is an error wrapping multiple validation errors
+// returned by ListTokensRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListTokensRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListTokensRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListTokensRequestMultiError) AllErrors() []error { return m }
+
+// ListTokensRequestValidationError is the validation error returned by
+// ListTokensRequest.Validate if the designated constraints aren't met.
+type ListTokensRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListTokensRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListTokensRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListTokensRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListTokensRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListTokensRequestValidationError) ErrorName() string {
+	return "ListTokensRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListTokensRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListTokensRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListTokensRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListTokensRequestValidationError{}
+
+// Validate checks the field values on ListTokensResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListTokensResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListTokensResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListTokensResponseMultiError, or nil if none found.
+func (m *ListTokensResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListTokensResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetTokens() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListTokensResponseValidationError{
+						field:  fmt.Sprintf("Tokens[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListTokensResponseValidationError{
+						field:  fmt.Sprintf("Tokens[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListTokensResponseValidationError{
+					field:  fmt.Sprintf("Tokens[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListTokensResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListTokensResponseMultiError is an error wrapping multiple validation errors
+// returned by ListTokensResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListTokensResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListTokensResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListTokensResponseMultiError) AllErrors() []error { return m }
+
+// ListTokensResponseValidationError is the validation error returned by
+// ListTokensResponse.Validate if the designated constraints aren't met.
+type ListTokensResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListTokensResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListTokensResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListTokensResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListTokensResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListTokensResponseValidationError) ErrorName() string {
+	return "ListTokensResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListTokensResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListTokensResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListTokensResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListTokensResponseValidationError{}
+
+// Validate checks the field values on RevokeTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeTokenRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeTokenRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeTokenRequestMultiError, or nil if none found.
+func (m *RevokeTokenRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeTokenRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetId()); l < 1 || l > 64 {
+		err := RevokeTokenRequestValidationError{
+			field:  "Id",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RevokeTokenRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeTokenRequestMultiError is an error wrapping multiple validation errors
+// returned by RevokeTokenRequest.ValidateAll() if the designated constraints
+// aren't met.
+type RevokeTokenRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeTokenRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeTokenRequestMultiError) AllErrors() []error { return m }
+
+// RevokeTokenRequestValidationError is the validation error returned by
+// RevokeTokenRequest.Validate if the designated constraints aren't met.
+type RevokeTokenRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeTokenRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeTokenRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeTokenRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeTokenRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeTokenRequestValidationError) ErrorName() string {
+	return "RevokeTokenRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeTokenRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeTokenRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeTokenRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeTokenRequestValidationError{}
+
+// Validate checks the field values on RevokeTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RevokeTokenResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RevokeTokenResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RevokeTokenResponseMultiError, or nil if none found.
+func (m *RevokeTokenResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RevokeTokenResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	if len(errors) > 0 {
+		return RevokeTokenResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RevokeTokenResponseMultiError is an error wrapping multiple validation
+// errors returned by RevokeTokenResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RevokeTokenResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RevokeTokenResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RevokeTokenResponseMultiError) AllErrors() []error { return m }
+
+// RevokeTokenResponseValidationError is the validation error returned by
+// RevokeTokenResponse.Validate if the designated constraints aren't met.
+type RevokeTokenResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RevokeTokenResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RevokeTokenResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RevokeTokenResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RevokeTokenResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RevokeTokenResponseValidationError) ErrorName() string {
+	return "RevokeTokenResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RevokeTokenResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRevokeTokenResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RevokeTokenResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RevokeTokenResponseValidationError{}
+
+// Validate checks the field values on User with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *User) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on User with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in UserMultiError, or nil if none found.
+func (m *User) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *User) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Username
+
+	// no validation rules for Email
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Plan
+
+	// no validation rules for Locale
+
+	// no validation rules for Notifications
+
+	// no validation rules for Role
+
+	// no validation rules for TotpEnabled
+
+	if len(errors) > 0 {
+		return UserMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserMultiError is an error wrapping multiple validation errors returned by
+// User.ValidateAll() if the designated constraints aren't met.
+type UserMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserMultiError) AllErrors() []error { return m }
+
+// UserValidationError is the validation error returned by User.Validate if the
+// designated constraints aren't met.
+type UserValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserValidationError) ErrorName() string { return "UserValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUser.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserValidationError{}
+
+// Validate checks the field values on CreateUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CreateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateUserRequestMultiError, or nil if none found.
+func (m *CreateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetUsername()); l < 3 || l > 32 {
+		err := CreateUserRequestValidationError{
+			field:  "Username",
+			reason: "value length must be between 3 and 32 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_CreateUserRequest_Username_Pattern.MatchString(m.GetUsername()) {
+		err := CreateUserRequestValidationError{
+			field:  "Username",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9][A-Za-z0-9._-]*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetEmail()) > 254 {
+		err := CreateUserRequestValidationError{
+			field:  "Email",
+			reason: "value length must be at most 254 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if err := m._validateEmail(m.GetEmail()); err != nil {
+		err = CreateUserRequestValidationError{
+			field:  "Email",
+			reason: "value must be a valid email address",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Password
+
+	if m.GetLocale() != "" {
+
+		if utf8.RuneCountInString(m.GetLocale()) > 35 {
+			err := CreateUserRequestValidationError{
+				field:  "Locale",
+				reason: "value length must be at most 35 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if !_CreateUserRequest_Locale_Pattern.MatchString(m.GetLocale()) {
+			err := CreateUserRequestValidationError{
+				field:  "Locale",
+				reason: "value does not match regex pattern \"^[A-Za-z]{2,3}([_-][A-Za-z0-9]{2,8})*$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CreateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *CreateUserRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *CreateUserRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// CreateUserRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateUserRequestMultiError) AllErrors() []error { return m }
+
+// CreateUserRequestValidationError is the validation error returned by
+// CreateUserRequest.Validate if the designated constraints aren't met.
+type CreateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateUserRequestValidationError) ErrorName() string {
+	return "CreateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateUserRequestValidationError{}
+
+var _CreateUserRequest_Username_Pattern = regexp.MustCompile("^[A-Za-z0-9][A-Za-z0-9._-]*$")
+
+var _CreateUserRequest_Locale_Pattern = regexp.MustCompile("^[A-Za-z]{2,3}([_-][A-Za-z0-9]{2,8})*$")
+
+// Validate checks the field values on GetUserRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetUserRequestMultiError,
+// or nil if none found.
+func (m *GetUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetId()); l < 1 || l > 64 {
+		err := GetUserRequestValidationError{
+			field:  "Id",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUserRequestMultiError is an error wrapping multiple validation errors
+// returned by GetUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUserRequestMultiError) AllErrors() []error { return m }
+
+// GetUserRequestValidationError is the validation error returned by
+// GetUserRequest.Validate if the designated constraints aren't met.
+type GetUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUserRequestValidationError) ErrorName() string { return "GetUserRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUserRequestValidationError{}
+
+// Validate checks the field values on UpdateUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserRequestMultiError, or nil if none found.
+func (m *UpdateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetId()); l < 1 || l > 64 {
+		err := UpdateUserRequestValidationError{
+			field:  "Id",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetUsername() != "" {
+
+		if l := utf8.RuneCountInString(m.GetUsername()); l < 3 || l > 32 {
+			err := UpdateUserRequestValidationError{
+				field:  "Username",
+				reason: "value length must be between 3 and 32 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if !_UpdateUserRequest_Username_Pattern.MatchString(m.GetUsername()) {
+			err := UpdateUserRequestValidationError{
+				field:  "Username",
+				reason: "value does not match regex pattern \"^[A-Za-z0-9][A-Za-z0-9._-]*$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetEmail() != "" {
+
+		if utf8.RuneCountInString(m.GetEmail()) > 254 {
+			err := UpdateUserRequestValidationError{
+				field:  "Email",
+				reason: "value length must be at most 254 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if err := m._validateEmail(m.GetEmail()); err != nil {
+			err = UpdateUserRequestValidationError{
+				field:  "Email",
+				reason: "value must be a valid email address",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetPlan()) > 64 {
+		err := UpdateUserRequestValidationError{
+			field:  "Plan",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdateMask()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateUserRequestValidationError{
+					field:  "UpdateMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateUserRequestValidationError{
+					field:  "UpdateMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdateMask()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateUserRequestValidationError{
+				field:  "UpdateMask",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetLocale() != "" {
+
+		if utf8.RuneCountInString(m.GetLocale()) > 35 {
+			err := UpdateUserRequestValidationError{
+				field:  "Locale",
+				reason: "value length must be at most 35 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if !_UpdateUserRequest_Locale_Pattern.MatchString(m.GetLocale()) {
+			err := UpdateUserRequestValidationError{
+				field:  "Locale",
+				reason: "value does not match regex pattern \"^[A-Za-z]{2,3}([_-][A-Za-z0-9]{2,8})*$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetNotifications()) > 32 {
+		err := UpdateUserRequestValidationError{
+			field:  "Notifications",
+			reason: "value length must be at most 32 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetRole()) > 32 {
+		err := UpdateUserRequestValidationError{
+			field:  "Role",
+			reason: "value length must be at most 32 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetCurrentPassword()) > 72 {
+		err := UpdateUserRequestValidationError{
+			field:  "CurrentPassword",
+			reason: "value length must be at most 72 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UpdateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *UpdateUserRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *UpdateUserRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// UpdateUserRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserRequestMultiError) AllErrors() []error { return m }
+
+// UpdateUserRequestValidationError is the validation error returned by
+// UpdateUserRequest.Validate if the designated constraints aren't met.
+type UpdateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserRequestValidationError) ErrorName() string {
+	return "UpdateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserRequestValidationError{}
+
+var _UpdateUserRequest_Username_Pattern = regexp.MustCompile("^[A-Za-z0-9][A-Za-z0-9._-]*$")
+
+var _UpdateUserRequest_Locale_Pattern = regexp.MustCompile("^[A-Za-z]{2,3}([_-][A-Za-z0-9]{2,8})*$")
+
+// Validate checks the field values on DeleteUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DeleteUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteUserRequestMultiError, or nil if none found.
+func (m *DeleteUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetId()); l < 1 || l > 64 {
+		err := DeleteUserRequestValidationError{
+			field:  "Id",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteUserRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteUserRequestMultiError) AllErrors() []error { return m }
+
+// DeleteUserRequestValidationError is the validation error returned by
+// DeleteUserRequest.Validate if the designated constraints aren't met.
+type DeleteUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteUserRequestValidationError) ErrorName() string {
+	return "DeleteUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteUserRequestValidationError{}
+
+// Validate checks the field values on DeleteUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteUserResponseMultiError, or nil if none found.
+func (m *DeleteUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	if all {
+		switch v := interface{}(m.GetRestorableUntil()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DeleteUserResponseValidationError{
+					field:  "RestorableUntil",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DeleteUserResponseValidationError{
+					field:  "RestorableUntil",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRestorableUntil()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DeleteUserResponseValidationError{
+				field:  "RestorableUntil",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DeleteUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteUserResponseMultiError is an error wrapping multiple validation errors
+// returned by DeleteUserResponse.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteUserResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteUserResponseMultiError) AllErrors() []error { return m }
+
+// DeleteUserResponseValidationError is the validation error returned by
+// DeleteUserResponse.Validate if the designated constraints aren't met.
+type DeleteUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteUserResponseValidationError) ErrorName() string {
+	return "DeleteUserResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteUserResponseValidationError{}
+
+// Validate checks the field values on GetUserByEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetUserByEmailRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUserByEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUserByEmailRequestMultiError, or nil if none found.
+func (m *GetUserByEmailRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUserByEmailRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetEmail()) > 254 {
+		err := GetUserByEmailRequestValidationError{
+			field:  "Email",
+			reason: "value length must be at most 254 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if err := m._validateEmail(m.GetEmail()); err != nil {
+		err = GetUserByEmailRequestValidationError{
+			field:  "Email",
+			reason: "value must be a valid email address",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetUserByEmailRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *GetUserByEmailRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *GetUserByEmailRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// GetUserByEmailRequestMultiError is an error wrapping multiple validation
+// errors returned by GetUserByEmailRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetUserByEmailRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUserByEmailRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUserByEmailRequestMultiError) AllErrors() []error { return m }
+
+// GetUserByEmailRequestValidationError is the validation error returned by
+// GetUserByEmailRequest.Validate if the designated constraints aren't met.
+type GetUserByEmailRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUserByEmailRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUserByEmailRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUserByEmailRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUserByEmailRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUserByEmailRequestValidationError) ErrorName() string {
+	return "GetUserByEmailRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetUserByEmailRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUserByEmailRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUserByEmailRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUserByEmailRequestValidationError{}
+
+// Validate checks the field values on GetUserByEmailResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetUserByEmailResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUserByEmailResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUserByEmailResponseMultiError, or nil if none found.
+func (m *GetUserByEmailResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUserByEmailResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	if len(errors) > 0 {
+		return GetUserByEmailResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUserByEmailResponseMultiError is an error wrapping multiple validation
+// errors returned by GetUserByEmailResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetUserByEmailResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUserByEmailResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUserByEmailResponseMultiError) AllErrors() []error { return m }
+
+// GetUserByEmailResponseValidationError is the validation error returned by
+// GetUserByEmailResponse.Validate if the designated constraints aren't met.
+type GetUserByEmailResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUserByEmailResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUserByEmailResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUserByEmailResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUserByEmailResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUserByEmailResponseValidationError) ErrorName() string {
+	return "GetUserByEmailResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetUserByEmailResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUserByEmailResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUserByEmailResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUserByEmailResponseValidationError{}
+
+// Validate checks the field values on ListUsersRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListUsersRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUsersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUsersRequestMultiError, or nil if none found.
+func (m *ListUsersRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUsersRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListUsersRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUsersRequestMultiError is an error wrapping multiple validation errors
+// returned by ListUsersRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListUsersRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUsersRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUsersRequestMultiError) AllErrors() []error { return m }
+
+// ListUsersRequestValidationError is the validation error returned by
+// ListUsersRequest.Validate if the designated constraints aren't met.
+type ListUsersRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUsersRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUsersRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUsersRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUsersRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUsersRequestValidationError) ErrorName() string { return "ListUsersRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListUsersRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUsersRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUsersRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUsersRequestValidationError{}
+
+// Validate checks the field values on ListUsersResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListUsersResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUsersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUsersResponseMultiError, or nil if none found.
+func (m *ListUsersResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUsersResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetUsers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListUsersResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListUsersResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUsersResponseValidationError{
+					field:  fmt.Sprintf("Users[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListUsersResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUsersResponseMultiError is an error wrapping multiple validation errors
+// returned by ListUsersResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListUsersResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUsersResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUsersResponseMultiError) AllErrors() []error { return m }
+
+// ListUsersResponseValidationError is the validation error returned by
+// ListUsersResponse.Validate if the designated constraints aren't met.
+type ListUsersResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUsersResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUsersResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUsersResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUsersResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUsersResponseValidationError) ErrorName() string {
+	return "ListUsersResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUsersResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUsersResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUsersResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUsersResponseValidationError{}
+
+// Validate checks the field values on UndeleteUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UndeleteUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UndeleteUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UndeleteUserRequestMultiError, or nil if none found.
+func (m *UndeleteUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UndeleteUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetId()); l < 1 || l > 64 {
+		err := UndeleteUserRequestValidationError{
+			field:  "Id",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UndeleteUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UndeleteUserRequestMultiError is an error wrapping multiple validation
+// errors returned by UndeleteUserRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UndeleteUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UndeleteUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UndeleteUserRequestMultiError) AllErrors() []error { return m }
+
+// UndeleteUserRequestValidationError is the validation error returned by
+// UndeleteUserRequest.Validate if the designated constraints aren't met.
+type UndeleteUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UndeleteUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UndeleteUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UndeleteUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UndeleteUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UndeleteUserRequestValidationError) ErrorName() string {
+	return "UndeleteUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UndeleteUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUndeleteUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UndeleteUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UndeleteUserRequestValidationError{}
+
+// Validate checks the field values on RestoreUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RestoreUserRequestMultiError, or nil if none found.
+func (m *RestoreUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetToken()); l < 1 || l > 256 {
+		err := RestoreUserRequestValidationError{
+			field:  "Token",
+			reason: "value length must be between 1 and 256 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RestoreUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RestoreUserRequestMultiError is an error wrapping multiple validation errors
+// returned by RestoreUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type RestoreUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreUserRequestMultiError) AllErrors() []error { return m }
+
+// RestoreUserRequestValidationError is the validation error returned by
+// RestoreUserRequest.Validate if the designated constraints aren't met.
+type RestoreUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreUserRequestValidationError) ErrorName() string {
+	return "RestoreUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreUserRequestValidationError{}
+
+// Validate checks the field values on ExportUserDataRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExportUserDataRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportUserDataRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExportUserDataRequestMultiError, or nil if none found.
+func (m *ExportUserDataRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportUserDataRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserId()) > 64 {
+		err := ExportUserDataRequestValidationError{
+			field:  "UserId",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := ExportFormat_name[int32(m.GetFormat())]; !ok {
+		err := ExportUserDataRequestValidationError{
+			field:  "Format",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ExportUserDataRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportUserDataRequestMultiError is an error wrapping multiple validation
+// errors returned by ExportUserDataRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ExportUserDataRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportUserDataRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportUserDataRequestMultiError) AllErrors() []error { return m }
+
+// ExportUserDataRequestValidationError is the validation error returned by
+// ExportUserDataRequest.Validate if the designated constraints aren't met.
+type ExportUserDataRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportUserDataRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportUserDataRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportUserDataRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportUserDataRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportUserDataRequestValidationError) ErrorName() string {
+	return "ExportUserDataRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExportUserDataRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportUserDataRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportUserDataRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportUserDataRequestValidationError{}
+
+// Validate checks the field values on ExportUserDataChunk with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExportUserDataChunk) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportUserDataChunk with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExportUserDataChunkMultiError, or nil if none found.
+func (m *ExportUserDataChunk) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportUserDataChunk) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Filename
+
+	// no validation rules for ContentType
+
+	// no validation rules for SchemaVersion
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return ExportUserDataChunkMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportUserDataChunkMultiError is an error wrapping multiple validation
+// errors returned by ExportUserDataChunk.ValidateAll() if the designated
+// constraints aren't met.
+type ExportUserDataChunkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportUserDataChunkMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportUserDataChunkMultiError) AllErrors() []error { return m }
+
+// ExportUserDataChunkValidationError is the validation error returned by
+// ExportUserDataChunk.Validate if the designated constraints aren't met.
+type ExportUserDataChunkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportUserDataChunkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportUserDataChunkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportUserDataChunkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportUserDataChunkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportUserDataChunkValidationError) ErrorName() string {
+	return "ExportUserDataChunkValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExportUserDataChunkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportUserDataChunk.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportUserDataChunkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportUserDataChunkValidationError{}
+
+// Validate checks the field values on Email with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Email) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Email with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in EmailMultiError, or nil if none found.
+func (m *Email) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Email) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for UserId
+
+	// no validation rules for Address
+
+	// no validation rules for Verified
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EmailValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EmailValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EmailValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EmailValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EmailValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EmailValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for PgpFingerprint
+
+	if len(errors) > 0 {
+		return EmailMultiError(errors)
+	}
+
+	return nil
+}
+
+// EmailMultiError is an error wrapping multiple validation errors returned by
+// Email.ValidateAll() if the designated constraints aren't met.
+type EmailMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EmailMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EmailMultiError) AllErrors() []error { return m }
+
+// EmailValidationError is the validation error returned by Email.Validate if
+// the designated constraints aren't met.
+type EmailValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EmailValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EmailValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EmailValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EmailValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EmailValidationError) ErrorName() string { return "EmailValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EmailValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEmail.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EmailValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EmailValidationError{}
+
+// Validate checks the field values on RegisterEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RegisterEmailRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RegisterEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RegisterEmailRequestMultiError, or nil if none found.
+func (m *RegisterEmailRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RegisterEmailRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserId()) > 64 {
+		err := RegisterEmailRequestValidationError{
+			field:  "UserId",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetEmailAddress()) > 254 {
+		err := RegisterEmailRequestValidationError{
+			field:  "EmailAddress",
+			reason: "value length must be at most 254 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if err := m._validateEmail(m.GetEmailAddress()); err != nil {
+		err = RegisterEmailRequestValidationError{
+			field:  "EmailAddress",
+			reason: "value must be a valid email address",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := VerificationMethod_name[int32(m.GetVerificationMethod())]; !ok {
+		err := RegisterEmailRequestValidationError{
+			field:  "VerificationMethod",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RegisterEmailRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *RegisterEmailRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *RegisterEmailRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// RegisterEmailRequestMultiError is an error wrapping multiple validation
+// errors returned by RegisterEmailRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RegisterEmailRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RegisterEmailRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RegisterEmailRequestMultiError) AllErrors() []error { return m }
+
+// RegisterEmailRequestValidationError is the validation error returned by
+// RegisterEmailRequest.Validate if the designated constraints aren't met.
+type RegisterEmailRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RegisterEmailRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RegisterEmailRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RegisterEmailRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RegisterEmailRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RegisterEmailRequestValidationError) ErrorName() string {
+	return "RegisterEmailRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RegisterEmailRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRegisterEmailRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RegisterEmailRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RegisterEmailRequestValidationError{}
+
+// Validate checks the field values on VerifyEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *VerifyEmailRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VerifyEmailRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// VerifyEmailRequestMultiError, or nil if none found.
+func (m *VerifyEmailRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VerifyEmailRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetToken()) > 256 {
+		err := VerifyEmailRequestValidationError{
+			field:  "Token",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetEmailId()) > 64 {
+		err := VerifyEmailRequestValidationError{
+			field:  "EmailId",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetCode()) > 32 {
+		err := VerifyEmailRequestValidationError{
+			field:  "Code",
+			reason: "value length must be at most 32 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return VerifyEmailRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// VerifyEmailRequestMultiError is an error wrapping multiple validation errors
+// returned by VerifyEmailRequest.ValidateAll() if the designated constraints
+// aren't met.
+type VerifyEmailRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VerifyEmailRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VerifyEmailRequestMultiError) AllErrors() []error { return m }
+
+// VerifyEmailRequestValidationError is the validation error returned by
+// VerifyEmailRequest.Validate if the designated constraints aren't met.
+type VerifyEmailRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VerifyEmailRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VerifyEmailRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VerifyEmailRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VerifyEmailRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VerifyEmailRequestValidationError) ErrorName() string {
+	return "VerifyEmailRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e VerifyEmailRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVerifyEmailRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VerifyEmailRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VerifyEmailRequestValidationError{}
+
+// Validate checks the field values on ResendVerificationRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResendVerificationRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResendVerificationRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResendVerificationRequestMultiError, or nil if none found.
+func (m *ResendVerificationRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResendVerificationRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetEmailId()); l < 1 || l > 64 {
+		err := ResendVerificationRequestValidationError{
+			field:  "EmailId",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := VerificationMethod_name[int32(m.GetVerificationMethod())]; !ok {
+		err := ResendVerificationRequestValidationError{
+			field:  "VerificationMethod",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ResendVerificationRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResendVerificationRequestMultiError is an error wrapping multiple validation
+// errors returned by ResendVerificationRequest.ValidateAll() if the
+// designated constraints aren't met.
+type ResendVerificationRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResendVerificationRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResendVerificationRequestMultiError) AllErrors() []error { return m }
+
+// ResendVerificationRequestValidationError is the validation error returned by
+// ResendVerificationRequest.Validate if the designated constraints aren't met.
+type ResendVerificationRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResendVerificationRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResendVerificationRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResendVerificationRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResendVerificationRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResendVerificationRequestValidationError) ErrorName() string {
+	return "ResendVerificationRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResendVerificationRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResendVerificationRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResendVerificationRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResendVerificationRequestValidationError{}
+
+// Validate checks the field values on ResendVerificationResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResendVerificationResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResendVerificationResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResendVerificationResponseMultiError, or nil if none found.
+func (m *ResendVerificationResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResendVerificationResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ResendVerificationResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ResendVerificationResponseValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ResendVerificationResponseValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ResendVerificationResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResendVerificationResponseMultiError is an error wrapping multiple
+// validation errors returned by ResendVerificationResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ResendVerificationResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResendVerificationResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResendVerificationResponseMultiError) AllErrors() []error { return m }
+
+// ResendVerificationResponseValidationError is the validation error returned
+// by ResendVerificationResponse.Validate if the designated constraints aren't met.
+type ResendVerificationResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResendVerificationResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResendVerificationResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResendVerificationResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResendVerificationResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResendVerificationResponseValidationError) ErrorName() string {
+	return "ResendVerificationResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResendVerificationResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResendVerificationResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResendVerificationResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResendVerificationResponseValidationError{}
+
+// Validate checks the field values on SetPGPKeyRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SetPGPKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetPGPKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SetPGPKeyRequestMultiError, or nil if none found.
+func (m *SetPGPKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetPGPKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetEmailId()); l < 1 || l > 64 {
+		err := SetPGPKeyRequestValidationError{
+			field:  "EmailId",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetPublicKey()) < 1 {
+		err := SetPGPKeyRequestValidationError{
+			field:  "PublicKey",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetPublicKey()) > 65536 {
+		err := SetPGPKeyRequestValidationError{
+			field:  "PublicKey",
+			reason: "value length must be at most 65536 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetFingerprint()); l < 1 || l > 128 {
+		err := SetPGPKeyRequestValidationError{
+			field:  "Fingerprint",
+			reason: "value length must be between 1 and 128 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return SetPGPKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SetPGPKeyRequestMultiError is an error wrapping multiple validation errors
+// returned by SetPGPKeyRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SetPGPKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetPGPKeyRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetPGPKeyRequestMultiError) AllErrors() []error { return m }
+
+// SetPGPKeyRequestValidationError is the validation error returned by
+// SetPGPKeyRequest.Validate if the designated constraints aren't met.
+type SetPGPKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetPGPKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetPGPKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetPGPKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetPGPKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetPGPKeyRequestValidationError) ErrorName() string { return "SetPGPKeyRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SetPGPKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetPGPKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetPGPKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetPGPKeyRequestValidationError{}
+
+// Validate checks the field values on RemovePGPKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RemovePGPKeyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RemovePGPKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RemovePGPKeyRequestMultiError, or nil if none found.
+func (m *RemovePGPKeyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RemovePGPKeyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetEmailId()); l < 1 || l > 64 {
+		err := RemovePGPKeyRequestValidationError{
+			field:  "EmailId",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RemovePGPKeyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RemovePGPKeyRequestMultiError is an error wrapping multiple validation
+// errors returned by RemovePGPKeyRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RemovePGPKeyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RemovePGPKeyRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RemovePGPKeyRequestMultiError) AllErrors() []error { return m }
+
+// RemovePGPKeyRequestValidationError is the validation error returned by
+// RemovePGPKeyRequest.Validate if the designated constraints aren't met.
+type RemovePGPKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RemovePGPKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RemovePGPKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RemovePGPKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RemovePGPKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RemovePGPKeyRequestValidationError) ErrorName() string {
+	return "RemovePGPKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RemovePGPKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRemovePGPKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RemovePGPKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RemovePGPKeyRequestValidationError{}
+
+// Validate checks the field values on Alias with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Alias) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Alias with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in AliasMultiError, or nil if none found.
+func (m *Alias) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Alias) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for UserId
+
+	// no validation rules for EmailId
+
+	// no validation rules for AliasAddress
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AliasValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AliasValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AliasValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AliasValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AliasValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AliasValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Website
+
+	if all {
+		switch v := interface{}(m.GetDeletedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AliasValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AliasValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AliasValidationError{
+				field:  "DeletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return AliasMultiError(errors)
+	}
+
+	return nil
+}
+
+// AliasMultiError is an error wrapping multiple validation errors returned by
+// Alias.ValidateAll() if the designated constraints aren't met.
+type AliasMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AliasMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AliasMultiError) AllErrors() []error { return m }
+
+// AliasValidationError is the validation error returned by Alias.Validate if
+// the designated constraints aren't met.
+type AliasValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AliasValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AliasValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AliasValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AliasValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AliasValidationError) ErrorName() string { return "AliasValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AliasValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAlias.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AliasValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AliasValidationError{}
+
+// Validate checks the field values on CreateAliasRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateAliasRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateAliasRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateAliasRequestMultiError, or nil if none found.
+func (m *CreateAliasRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateAliasRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserId()) > 64 {
+		err := CreateAliasRequestValidationError{
+			field:  "UserId",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetEmailId()); l < 1 || l > 64 {
+		err := CreateAliasRequestValidationError{
+			field:  "EmailId",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetAliasPrefix()); l < 1 || l > 64 {
+		err := CreateAliasRequestValidationError{
+			field:  "AliasPrefix",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_CreateAliasRequest_AliasPrefix_Pattern.MatchString(m.GetAliasPrefix()) {
+		err := CreateAliasRequestValidationError{
+			field:  "AliasPrefix",
+			reason: "value does not match regex pattern \"^[a-z0-9]+([._-][a-z0-9]+)*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetDomain() != "" {
+
+		if err := m._validateHostname(m.GetDomain()); err != nil {
+			err = CreateAliasRequestValidationError{
+				field:  "Domain",
+				reason: "value must be a valid hostname",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CreateAliasRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *CreateAliasRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+// CreateAliasRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateAliasRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateAliasRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateAliasRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateAliasRequestMultiError) AllErrors() []error { return m }
+
+// CreateAliasRequestValidationError is the validation error returned by
+// CreateAliasRequest.Validate if the designated constraints aren't met.
+type CreateAliasRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateAliasRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateAliasRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateAliasRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateAliasRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateAliasRequestValidationError) ErrorName() string {
+	return "CreateAliasRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateAliasRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateAliasRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateAliasRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateAliasRequestValidationError{}
+
+var _CreateAliasRequest_AliasPrefix_Pattern = regexp.MustCompile("^[a-z0-9]+([._-][a-z0-9]+)*$")
+
+// Validate checks the field values on QuickCreateAliasRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *QuickCreateAliasRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QuickCreateAliasRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QuickCreateAliasRequestMultiError, or nil if none found.
+func (m *QuickCreateAliasRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QuickCreateAliasRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserId()) > 64 {
+		err := QuickCreateAliasRequestValidationError{
+			field:  "UserId",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetEmailId()) > 64 {
+		err := QuickCreateAliasRequestValidationError{
+			field:  "EmailId",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetHostname()); l < 1 || l > 2048 {
+		err := QuickCreateAliasRequestValidationError{
+			field:  "Hostname",
+			reason: "value length must be between 1 and 2048 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetDomain() != "" {
+
+		if err := m._validateHostname(m.GetDomain()); err != nil {
+			err = QuickCreateAliasRequestValidationError{
+				field:  "Domain",
+				reason: "value must be a valid hostname",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return QuickCreateAliasRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *QuickCreateAliasRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+// QuickCreateAliasRequestMultiError is an error wrapping multiple validation
+// errors returned by QuickCreateAliasRequest.ValidateAll() if the designated
+// constraints aren't met.
+type QuickCreateAliasRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QuickCreateAliasRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QuickCreateAliasRequestMultiError) AllErrors() []error { return m }
+
+// QuickCreateAliasRequestValidationError is the validation error returned by
+// QuickCreateAliasRequest.Validate if the designated constraints aren't met.
+type QuickCreateAliasRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QuickCreateAliasRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QuickCreateAliasRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QuickCreateAliasRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QuickCreateAliasRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QuickCreateAliasRequestValidationError) ErrorName() string {
+	return "QuickCreateAliasRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e QuickCreateAliasRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQuickCreateAliasRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QuickCreateAliasRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QuickCreateAliasRequestValidationError{}
+
+// Validate checks the field values on QuickCreateAliasResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *QuickCreateAliasResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QuickCreateAliasResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QuickCreateAliasResponseMultiError, or nil if none found.
+func (m *QuickCreateAliasResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QuickCreateAliasResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetAlias()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QuickCreateAliasResponseValidationError{
+					field:  "Alias",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QuickCreateAliasResponseValidationError{
+					field:  "Alias",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAlias()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QuickCreateAliasResponseValidationError{
+				field:  "Alias",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Created
+
+	if len(errors) > 0 {
+		return QuickCreateAliasResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// QuickCreateAliasResponseMultiError is an error wrapping multiple validation
+// errors returned by QuickCreateAliasResponse.ValidateAll() if the designated
+// constraints aren't met.
+type QuickCreateAliasResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QuickCreateAliasResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QuickCreateAliasResponseMultiError) AllErrors() []error { return m }
+
+// QuickCreateAliasResponseValidationError is the validation error returned by
+// QuickCreateAliasResponse.Validate if the designated constraints aren't met.
+type QuickCreateAliasResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QuickCreateAliasResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QuickCreateAliasResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QuickCreateAliasResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QuickCreateAliasResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QuickCreateAliasResponseValidationError) ErrorName() string {
+	return "QuickCreateAliasResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e QuickCreateAliasResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQuickCreateAliasResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QuickCreateAliasResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QuickCreateAliasResponseValidationError{}
+
+// Validate checks the field values on GetAliasRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetAliasRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetAliasRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetAliasRequestMultiError, or nil if none found.
+func (m *GetAliasRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetAliasRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetId()); l < 1 || l > 64 {
+		err := GetAliasRequestValidationError{
+			field:  "Id",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetAliasRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetAliasRequestMultiError is an error wrapping multiple validation errors
+// returned by GetAliasRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetAliasRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetAliasRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetAliasRequestMultiError) AllErrors() []error { return m }
+
+// GetAliasRequestValidationError is the validation error returned by
+// GetAliasRequest.Validate if the designated constraints aren't met.
+type GetAliasRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetAliasRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetAliasRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetAliasRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetAliasRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetAliasRequestValidationError) ErrorName() string { return "GetAliasRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetAliasRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetAliasRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetAliasRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetAliasRequestValidationError{}
+
+// Validate checks the field values on UpdateAliasRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateAliasRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateAliasRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateAliasRequestMultiError, or nil if none found.
+func (m *UpdateAliasRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateAliasRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetId()); l < 1 || l > 64 {
+		err := UpdateAliasRequestValidationError{
+			field:  "Id",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetEmailId()) > 64 {
+		err := UpdateAliasRequestValidationError{
+			field:  "EmailId",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetAliasPrefix() != "" {
+
+		if l := utf8.RuneCountInString(m.GetAliasPrefix()); l < 1 || l > 64 {
+			err := UpdateAliasRequestValidationError{
+				field:  "AliasPrefix",
+				reason: "value length must be between 1 and 64 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if !_UpdateAliasRequest_AliasPrefix_Pattern.MatchString(m.GetAliasPrefix()) {
+			err := UpdateAliasRequestValidationError{
+				field:  "AliasPrefix",
+				reason: "value does not match regex pattern \"^[a-z0-9]+([._-][a-z0-9]+)*$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdateMask()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateAliasRequestValidationError{
+					field:  "UpdateMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateAliasRequestValidationError{
+					field:  "UpdateMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdateMask()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateAliasRequestValidationError{
+				field:  "UpdateMask",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateAliasRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateAliasRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateAliasRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateAliasRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateAliasRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateAliasRequestMultiError) AllErrors() []error { return m }
+
+// UpdateAliasRequestValidationError is the validation error returned by
+// UpdateAliasRequest.Validate if the designated constraints aren't met.
+type UpdateAliasRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateAliasRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateAliasRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateAliasRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateAliasRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateAliasRequestValidationError) ErrorName() string {
+	return "UpdateAliasRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateAliasRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateAliasRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateAliasRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateAliasRequestValidationError{}
+
+var _UpdateAliasRequest_AliasPrefix_Pattern = regexp.MustCompile("^[a-z0-9]+([._-][a-z0-9]+)*$")
+
+// Validate checks the field values on DeleteAliasRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteAliasRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteAliasRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteAliasRequestMultiError, or nil if none found.
+func (m *DeleteAliasRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteAliasRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetId()); l < 1 || l > 64 {
+		err := DeleteAliasRequestValidationError{
+			field:  "Id",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteAliasRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteAliasRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteAliasRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteAliasRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteAliasRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteAliasRequestMultiError) AllErrors() []error { return m }
+
+// DeleteAliasRequestValidationError is the validation error returned by
+// DeleteAliasRequest.Validate if the designated constraints aren't met.
+type DeleteAliasRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteAliasRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteAliasRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteAliasRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteAliasRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteAliasRequestValidationError) ErrorName() string {
+	return "DeleteAliasRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteAliasRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteAliasRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteAliasRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteAliasRequestValidationError{}
+
+// Validate checks the field values on DeleteAliasResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteAliasResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteAliasResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteAliasResponseMultiError, or nil if none found.
+func (m *DeleteAliasResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteAliasResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	if len(errors) > 0 {
+		return DeleteAliasResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteAliasResponseMultiError is an error wrapping multiple validation
+// errors returned by DeleteAliasResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteAliasResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteAliasResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteAliasResponseMultiError) AllErrors() []error { return m }
+
+// DeleteAliasResponseValidationError is the validation error returned by
+// DeleteAliasResponse.Validate if the designated constraints aren't met.
+type DeleteAliasResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteAliasResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteAliasResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteAliasResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteAliasResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteAliasResponseValidationError) ErrorName() string {
+	return "DeleteAliasResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteAliasResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteAliasResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteAliasResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteAliasResponseValidationError{}
+
+// Validate checks the field values on ListAliasesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAliasesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAliasesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAliasesRequestMultiError, or nil if none found.
+func (m *ListAliasesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAliasesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserId()) > 64 {
+		err := ListAliasesRequestValidationError{
+			field:  "UserId",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListAliasesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAliasesRequestMultiError is an error wrapping multiple validation errors
+// returned by ListAliasesRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListAliasesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAliasesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAliasesRequestMultiError) AllErrors() []error { return m }
+
+// ListAliasesRequestValidationError is the validation error returned by
+// ListAliasesRequest.Validate if the designated constraints aren't met.
+type ListAliasesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAliasesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAliasesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAliasesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAliasesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAliasesRequestValidationError) ErrorName() string {
+	return "ListAliasesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAliasesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAliasesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAliasesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAliasesRequestValidationError{}
+
+// Validate checks the field values on ListAliasesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAliasesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAliasesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAliasesResponseMultiError, or nil if none found.
+func (m *ListAliasesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAliasesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetAliases() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAliasesResponseValidationError{
+						field:  fmt.Sprintf("Aliases[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAliasesResponseValidationError{
+						field:  fmt.Sprintf("Aliases[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAliasesResponseValidationError{
+					field:  fmt.Sprintf("Aliases[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListAliasesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAliasesResponseMultiError is an error wrapping multiple validation
+// errors returned by ListAliasesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListAliasesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAliasesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAliasesResponseMultiError) AllErrors() []error { return m }
+
+// ListAliasesResponseValidationError is the validation error returned by
+// ListAliasesResponse.Validate if the designated constraints aren't met.
+type ListAliasesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAliasesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAliasesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAliasesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAliasesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAliasesResponseValidationError) ErrorName() string {
+	return "ListAliasesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAliasesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAliasesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAliasesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAliasesResponseValidationError{}
+
+// Validate checks the field values on ListDeletedAliasesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListDeletedAliasesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDeletedAliasesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListDeletedAliasesRequestMultiError, or nil if none found.
+func (m *ListDeletedAliasesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDeletedAliasesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserId()) > 64 {
+		err := ListDeletedAliasesRequestValidationError{
+			field:  "UserId",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListDeletedAliasesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDeletedAliasesRequestMultiError is an error wrapping multiple validation
+// errors returned by ListDeletedAliasesRequest.ValidateAll() if the
+// designated constraints aren't met.
+type ListDeletedAliasesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDeletedAliasesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDeletedAliasesRequestMultiError) AllErrors() []error { return m }
+
+// ListDeletedAliasesRequestValidationError is the validation error returned by
+// ListDeletedAliasesRequest.Validate if the designated constraints aren't met.
+type ListDeletedAliasesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDeletedAliasesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDeletedAliasesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDeletedAliasesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDeletedAliasesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDeletedAliasesRequestValidationError) ErrorName() string {
+	return "ListDeletedAliasesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListDeletedAliasesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDeletedAliasesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDeletedAliasesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDeletedAliasesRequestValidationError{}
+
+// Validate checks the field values on ListDeletedAliasesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListDeletedAliasesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDeletedAliasesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListDeletedAliasesResponseMultiError, or nil if none found.
+func (m *ListDeletedAliasesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDeletedAliasesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetAliases() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListDeletedAliasesResponseValidationError{
+						field:  fmt.Sprintf("Aliases[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListDeletedAliasesResponseValidationError{
+						field:  fmt.Sprintf("Aliases[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListDeletedAliasesResponseValidationError{
+					field:  fmt.Sprintf("Aliases[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListDeletedAliasesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDeletedAliasesResponseMultiError is an error wrapping multiple
+// validation errors returned by ListDeletedAliasesResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ListDeletedAliasesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDeletedAliasesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDeletedAliasesResponseMultiError) AllErrors() []error { return m }
+
+// ListDeletedAliasesResponseValidationError is the validation error returned
+// by ListDeletedAliasesResponse.Validate if the designated constraints aren't met.
+type ListDeletedAliasesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDeletedAliasesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDeletedAliasesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDeletedAliasesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDeletedAliasesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDeletedAliasesResponseValidationError) ErrorName() string {
+	return "ListDeletedAliasesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListDeletedAliasesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDeletedAliasesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDeletedAliasesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDeletedAliasesResponseValidationError{}
+
+// Validate checks the field values on UndeleteAliasRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UndeleteAliasRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UndeleteAliasRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UndeleteAliasRequestMultiError, or nil if none found.
+func (m *UndeleteAliasRequest) ValidateAll() error {
+	return m.validate(true)
+}
 
-	// no validation rules for AliasPrefix
+func (m *UndeleteAliasRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetId()); l < 1 || l > 64 {
+		err := UndeleteAliasRequestValidationError{
+			field:  "Id",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return CreateAliasRequestMultiError(errors)
+		return UndeleteAliasRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// CreateAliasRequestMultiError is an error wrapping multiple validation errors
-// returned by CreateAliasRequest.ValidateAll() if the designated constraints
-// aren't met.
-type CreateAliasRequestMultiError []error
+// UndeleteAliasRequestMultiError is an error wrapping multiple validation
+// errors returned by UndeleteAliasRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UndeleteAliasRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m CreateAliasRequestMultiError) Error() string {
+func (m UndeleteAliasRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1755,11 +8196,11 @@ func (m CreateAliasRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m CreateAliasRequestMultiError) AllErrors() []error { return m }
+func (m UndeleteAliasRequestMultiError) AllErrors() []error { return m }
 
-// CreateAliasRequestValidationError is the validation error returned by
-// CreateAliasRequest.Validate if the designated constraints aren't met.
-type CreateAliasRequestValidationError struct {
+// UndeleteAliasRequestValidationError is the validation error returned by
+// UndeleteAliasRequest.Validate if the designated constraints aren't met.
+type UndeleteAliasRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1767,24 +8208,24 @@ type CreateAliasRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e CreateAliasRequestValidationError) Field() string { return e.field }
+func (e UndeleteAliasRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CreateAliasRequestValidationError) Reason() string { return e.reason }
+func (e UndeleteAliasRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CreateAliasRequestValidationError) Cause() error { return e.cause }
+func (e UndeleteAliasRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CreateAliasRequestValidationError) Key() bool { return e.key }
+func (e UndeleteAliasRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CreateAliasRequestValidationError) ErrorName() string {
-	return "CreateAliasRequestValidationError"
+func (e UndeleteAliasRequestValidationError) ErrorName() string {
+	return "UndeleteAliasRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e CreateAliasRequestValidationError) Error() string {
+func (e UndeleteAliasRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1796,14 +8237,14 @@ func (e CreateAliasRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCreateAliasRequest.%s: %s%s",
+		"invalid %sUndeleteAliasRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CreateAliasRequestValidationError{}
+var _ error = UndeleteAliasRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1811,46 +8252,361 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CreateAliasRequestValidationError{}
+} = UndeleteAliasRequestValidationError{}
 
-// Validate checks the field values on GetAliasRequest with the rules defined
+// Validate checks the field values on GetUsageRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *GetAliasRequest) Validate() error {
+func (m *GetUsageRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetAliasRequest with the rules
+// ValidateAll checks the field values on GetUsageRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetAliasRequestMultiError, or nil if none found.
-func (m *GetAliasRequest) ValidateAll() error {
+// GetUsageRequestMultiError, or nil if none found.
+func (m *GetUsageRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetAliasRequest) validate(all bool) error {
+func (m *GetUsageRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUserId()) > 64 {
+		err := GetUsageRequestValidationError{
+			field:  "UserId",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetUsageRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUsageRequestMultiError is an error wrapping multiple validation errors
+// returned by GetUsageRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetUsageRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUsageRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUsageRequestMultiError) AllErrors() []error { return m }
+
+// GetUsageRequestValidationError is the validation error returned by
+// GetUsageRequest.Validate if the designated constraints aren't met.
+type GetUsageRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUsageRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUsageRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUsageRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUsageRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUsageRequestValidationError) ErrorName() string { return "GetUsageRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetUsageRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUsageRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUsageRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUsageRequestValidationError{}
+
+// Validate checks the field values on Usage with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Usage) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Usage with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in UsageMultiError, or nil if none found.
+func (m *Usage) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Usage) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Plan
+
+	// no validation rules for Aliases
+
+	// no validation rules for MaxAliases
+
+	// no validation rules for Emails
+
+	// no validation rules for MaxEmails
+
+	if len(errors) > 0 {
+		return UsageMultiError(errors)
+	}
+
+	return nil
+}
+
+// UsageMultiError is an error wrapping multiple validation errors returned by
+// Usage.ValidateAll() if the designated constraints aren't met.
+type UsageMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UsageMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UsageMultiError) AllErrors() []error { return m }
+
+// UsageValidationError is the validation error returned by Usage.Validate if
+// the designated constraints aren't met.
+type UsageValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UsageValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UsageValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UsageValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UsageValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UsageValidationError) ErrorName() string { return "UsageValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UsageValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUsage.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UsageValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UsageValidationError{}
+
+// Validate checks the field values on OutgoingMail with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *OutgoingMail) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on OutgoingMail with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in OutgoingMailMultiError, or
+// nil if none found.
+func (m *OutgoingMail) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *OutgoingMail) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
-	var errors []error
-
-	// no validation rules for Id
-
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Recipient
+
+	// no validation rules for Subject
+
+	// no validation rules for Status
+
+	// no validation rules for Attempts
+
+	// no validation rules for LastError
+
+	if all {
+		switch v := interface{}(m.GetNextAttemptAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, OutgoingMailValidationError{
+					field:  "NextAttemptAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, OutgoingMailValidationError{
+					field:  "NextAttemptAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetNextAttemptAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return OutgoingMailValidationError{
+				field:  "NextAttemptAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, OutgoingMailValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, OutgoingMailValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return OutgoingMailValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetSentAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, OutgoingMailValidationError{
+					field:  "SentAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, OutgoingMailValidationError{
+					field:  "SentAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSentAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return OutgoingMailValidationError{
+				field:  "SentAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
-		return GetAliasRequestMultiError(errors)
+		return OutgoingMailMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetAliasRequestMultiError is an error wrapping multiple validation errors
-// returned by GetAliasRequest.ValidateAll() if the designated constraints
-// aren't met.
-type GetAliasRequestMultiError []error
+// OutgoingMailMultiError is an error wrapping multiple validation errors
+// returned by OutgoingMail.ValidateAll() if the designated constraints aren't met.
+type OutgoingMailMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetAliasRequestMultiError) Error() string {
+func (m OutgoingMailMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1859,11 +8615,11 @@ func (m GetAliasRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetAliasRequestMultiError) AllErrors() []error { return m }
+func (m OutgoingMailMultiError) AllErrors() []error { return m }
 
-// GetAliasRequestValidationError is the validation error returned by
-// GetAliasRequest.Validate if the designated constraints aren't met.
-type GetAliasRequestValidationError struct {
+// OutgoingMailValidationError is the validation error returned by
+// OutgoingMail.Validate if the designated constraints aren't met.
+type OutgoingMailValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1871,22 +8627,22 @@ type GetAliasRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetAliasRequestValidationError) Field() string { return e.field }
+func (e OutgoingMailValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetAliasRequestValidationError) Reason() string { return e.reason }
+func (e OutgoingMailValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetAliasRequestValidationError) Cause() error { return e.cause }
+func (e OutgoingMailValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetAliasRequestValidationError) Key() bool { return e.key }
+func (e OutgoingMailValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetAliasRequestValidationError) ErrorName() string { return "GetAliasRequestValidationError" }
+func (e OutgoingMailValidationError) ErrorName() string { return "OutgoingMailValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetAliasRequestValidationError) Error() string {
+func (e OutgoingMailValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1898,14 +8654,14 @@ func (e GetAliasRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetAliasRequest.%s: %s%s",
+		"invalid %sOutgoingMail.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetAliasRequestValidationError{}
+var _ error = OutgoingMailValidationError{}
 
 var _ interface {
 	Field() string
@@ -1913,50 +8669,66 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetAliasRequestValidationError{}
+} = OutgoingMailValidationError{}
 
-// Validate checks the field values on UpdateAliasRequest with the rules
+// Validate checks the field values on ListMailQueueRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UpdateAliasRequest) Validate() error {
+func (m *ListMailQueueRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateAliasRequest with the rules
+// ValidateAll checks the field values on ListMailQueueRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdateAliasRequestMultiError, or nil if none found.
-func (m *UpdateAliasRequest) ValidateAll() error {
+// ListMailQueueRequestMultiError, or nil if none found.
+func (m *ListMailQueueRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateAliasRequest) validate(all bool) error {
+func (m *ListMailQueueRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
-
-	// no validation rules for EmailId
+	if utf8.RuneCountInString(m.GetStatus()) > 32 {
+		err := ListMailQueueRequestValidationError{
+			field:  "Status",
+			reason: "value length must be at most 32 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
-	// no validation rules for AliasPrefix
+	if m.GetLimit() < 0 {
+		err := ListMailQueueRequestValidationError{
+			field:  "Limit",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return UpdateAliasRequestMultiError(errors)
+		return ListMailQueueRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateAliasRequestMultiError is an error wrapping multiple validation errors
-// returned by UpdateAliasRequest.ValidateAll() if the designated constraints
-// aren't met.
-type UpdateAliasRequestMultiError []error
+// ListMailQueueRequestMultiError is an error wrapping multiple validation
+// errors returned by ListMailQueueRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListMailQueueRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateAliasRequestMultiError) Error() string {
+func (m ListMailQueueRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1965,11 +8737,11 @@ func (m UpdateAliasRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateAliasRequestMultiError) AllErrors() []error { return m }
+func (m ListMailQueueRequestMultiError) AllErrors() []error { return m }
 
-// UpdateAliasRequestValidationError is the validation error returned by
-// UpdateAliasRequest.Validate if the designated constraints aren't met.
-type UpdateAliasRequestValidationError struct {
+// ListMailQueueRequestValidationError is the validation error returned by
+// ListMailQueueRequest.Validate if the designated constraints aren't met.
+type ListMailQueueRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1977,24 +8749,24 @@ type UpdateAliasRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateAliasRequestValidationError) Field() string { return e.field }
+func (e ListMailQueueRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateAliasRequestValidationError) Reason() string { return e.reason }
+func (e ListMailQueueRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateAliasRequestValidationError) Cause() error { return e.cause }
+func (e ListMailQueueRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateAliasRequestValidationError) Key() bool { return e.key }
+func (e ListMailQueueRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateAliasRequestValidationError) ErrorName() string {
-	return "UpdateAliasRequestValidationError"
+func (e ListMailQueueRequestValidationError) ErrorName() string {
+	return "ListMailQueueRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdateAliasRequestValidationError) Error() string {
+func (e ListMailQueueRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2006,14 +8778,14 @@ func (e UpdateAliasRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateAliasRequest.%s: %s%s",
+		"invalid %sListMailQueueRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateAliasRequestValidationError{}
+var _ error = ListMailQueueRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2021,46 +8793,78 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateAliasRequestValidationError{}
+} = ListMailQueueRequestValidationError{}
 
-// Validate checks the field values on DeleteAliasRequest with the rules
+// Validate checks the field values on ListMailQueueResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *DeleteAliasRequest) Validate() error {
+func (m *ListMailQueueResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on DeleteAliasRequest with the rules
+// ValidateAll checks the field values on ListMailQueueResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// DeleteAliasRequestMultiError, or nil if none found.
-func (m *DeleteAliasRequest) ValidateAll() error {
+// ListMailQueueResponseMultiError, or nil if none found.
+func (m *ListMailQueueResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *DeleteAliasRequest) validate(all bool) error {
+func (m *ListMailQueueResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
+	for idx, item := range m.GetMessages() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListMailQueueResponseValidationError{
+						field:  fmt.Sprintf("Messages[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListMailQueueResponseValidationError{
+						field:  fmt.Sprintf("Messages[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListMailQueueResponseValidationError{
+					field:  fmt.Sprintf("Messages[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
 
 	if len(errors) > 0 {
-		return DeleteAliasRequestMultiError(errors)
+		return ListMailQueueResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// DeleteAliasRequestMultiError is an error wrapping multiple validation errors
-// returned by DeleteAliasRequest.ValidateAll() if the designated constraints
-// aren't met.
-type DeleteAliasRequestMultiError []error
+// ListMailQueueResponseMultiError is an error wrapping multiple validation
+// errors returned by ListMailQueueResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListMailQueueResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m DeleteAliasRequestMultiError) Error() string {
+func (m ListMailQueueResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2069,11 +8873,11 @@ func (m DeleteAliasRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m DeleteAliasRequestMultiError) AllErrors() []error { return m }
+func (m ListMailQueueResponseMultiError) AllErrors() []error { return m }
 
-// DeleteAliasRequestValidationError is the validation error returned by
-// DeleteAliasRequest.Validate if the designated constraints aren't met.
-type DeleteAliasRequestValidationError struct {
+// ListMailQueueResponseValidationError is the validation error returned by
+// ListMailQueueResponse.Validate if the designated constraints aren't met.
+type ListMailQueueResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2081,24 +8885,24 @@ type DeleteAliasRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e DeleteAliasRequestValidationError) Field() string { return e.field }
+func (e ListMailQueueResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e DeleteAliasRequestValidationError) Reason() string { return e.reason }
+func (e ListMailQueueResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e DeleteAliasRequestValidationError) Cause() error { return e.cause }
+func (e ListMailQueueResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e DeleteAliasRequestValidationError) Key() bool { return e.key }
+func (e ListMailQueueResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e DeleteAliasRequestValidationError) ErrorName() string {
-	return "DeleteAliasRequestValidationError"
+func (e ListMailQueueResponseValidationError) ErrorName() string {
+	return "ListMailQueueResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e DeleteAliasRequestValidationError) Error() string {
+func (e ListMailQueueResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2110,14 +8914,14 @@ func (e DeleteAliasRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sDeleteAliasRequest.%s: %s%s",
+		"invalid %sListMailQueueResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = DeleteAliasRequestValidationError{}
+var _ error = ListMailQueueResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2125,46 +8929,55 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = DeleteAliasRequestValidationError{}
+} = ListMailQueueResponseValidationError{}
 
-// Validate checks the field values on DeleteAliasResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *DeleteAliasResponse) Validate() error {
+// Validate checks the field values on RetryMailRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *RetryMailRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on DeleteAliasResponse with the rules
+// ValidateAll checks the field values on RetryMailRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// DeleteAliasResponseMultiError, or nil if none found.
-func (m *DeleteAliasResponse) ValidateAll() error {
+// RetryMailRequestMultiError, or nil if none found.
+func (m *RetryMailRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *DeleteAliasResponse) validate(all bool) error {
+func (m *RetryMailRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Success
+	if l := utf8.RuneCountInString(m.GetId()); l < 1 || l > 64 {
+		err := RetryMailRequestValidationError{
+			field:  "Id",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return DeleteAliasResponseMultiError(errors)
+		return RetryMailRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// DeleteAliasResponseMultiError is an error wrapping multiple validation
-// errors returned by DeleteAliasResponse.ValidateAll() if the designated
-// constraints aren't met.
-type DeleteAliasResponseMultiError []error
+// RetryMailRequestMultiError is an error wrapping multiple validation errors
+// returned by RetryMailRequest.ValidateAll() if the designated constraints
+// aren't met.
+type RetryMailRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m DeleteAliasResponseMultiError) Error() string {
+func (m RetryMailRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2173,11 +8986,11 @@ func (m DeleteAliasResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m DeleteAliasResponseMultiError) AllErrors() []error { return m }
+func (m RetryMailRequestMultiError) AllErrors() []error { return m }
 
-// DeleteAliasResponseValidationError is the validation error returned by
-// DeleteAliasResponse.Validate if the designated constraints aren't met.
-type DeleteAliasResponseValidationError struct {
+// RetryMailRequestValidationError is the validation error returned by
+// RetryMailRequest.Validate if the designated constraints aren't met.
+type RetryMailRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2185,24 +8998,22 @@ type DeleteAliasResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e DeleteAliasResponseValidationError) Field() string { return e.field }
+func (e RetryMailRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e DeleteAliasResponseValidationError) Reason() string { return e.reason }
+func (e RetryMailRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e DeleteAliasResponseValidationError) Cause() error { return e.cause }
+func (e RetryMailRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e DeleteAliasResponseValidationError) Key() bool { return e.key }
+func (e RetryMailRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e DeleteAliasResponseValidationError) ErrorName() string {
-	return "DeleteAliasResponseValidationError"
-}
+func (e RetryMailRequestValidationError) ErrorName() string { return "RetryMailRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e DeleteAliasResponseValidationError) Error() string {
+func (e RetryMailRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2214,14 +9025,14 @@ func (e DeleteAliasResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sDeleteAliasResponse.%s: %s%s",
+		"invalid %sRetryMailRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = DeleteAliasResponseValidationError{}
+var _ error = RetryMailRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2229,46 +9040,55 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = DeleteAliasResponseValidationError{}
+} = RetryMailRequestValidationError{}
 
-// Validate checks the field values on ListAliasesRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ListAliasesRequest) Validate() error {
+// Validate checks the field values on ResetTOTPRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ResetTOTPRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListAliasesRequest with the rules
+// ValidateAll checks the field values on ResetTOTPRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ListAliasesRequestMultiError, or nil if none found.
-func (m *ListAliasesRequest) ValidateAll() error {
+// ResetTOTPRequestMultiError, or nil if none found.
+func (m *ResetTOTPRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListAliasesRequest) validate(all bool) error {
+func (m *ResetTOTPRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for UserId
+	if l := utf8.RuneCountInString(m.GetUserId()); l < 1 || l > 64 {
+		err := ResetTOTPRequestValidationError{
+			field:  "UserId",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
 
 	if len(errors) > 0 {
-		return ListAliasesRequestMultiError(errors)
+		return ResetTOTPRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListAliasesRequestMultiError is an error wrapping multiple validation errors
-// returned by ListAliasesRequest.ValidateAll() if the designated constraints
+// ResetTOTPRequestMultiError is an error wrapping multiple validation errors
+// returned by ResetTOTPRequest.ValidateAll() if the designated constraints
 // aren't met.
-type ListAliasesRequestMultiError []error
+type ResetTOTPRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListAliasesRequestMultiError) Error() string {
+func (m ResetTOTPRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2277,11 +9097,11 @@ func (m ListAliasesRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListAliasesRequestMultiError) AllErrors() []error { return m }
+func (m ResetTOTPRequestMultiError) AllErrors() []error { return m }
 
-// ListAliasesRequestValidationError is the validation error returned by
-// ListAliasesRequest.Validate if the designated constraints aren't met.
-type ListAliasesRequestValidationError struct {
+// ResetTOTPRequestValidationError is the validation error returned by
+// ResetTOTPRequest.Validate if the designated constraints aren't met.
+type ResetTOTPRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2289,24 +9109,22 @@ type ListAliasesRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListAliasesRequestValidationError) Field() string { return e.field }
+func (e ResetTOTPRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListAliasesRequestValidationError) Reason() string { return e.reason }
+func (e ResetTOTPRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListAliasesRequestValidationError) Cause() error { return e.cause }
+func (e ResetTOTPRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListAliasesRequestValidationError) Key() bool { return e.key }
+func (e ResetTOTPRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListAliasesRequestValidationError) ErrorName() string {
-	return "ListAliasesRequestValidationError"
-}
+func (e ResetTOTPRequestValidationError) ErrorName() string { return "ResetTOTPRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e ListAliasesRequestValidationError) Error() string {
+func (e ResetTOTPRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2318,14 +9136,14 @@ func (e ListAliasesRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListAliasesRequest.%s: %s%s",
+		"invalid %sResetTOTPRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListAliasesRequestValidationError{}
+var _ error = ResetTOTPRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2333,78 +9151,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListAliasesRequestValidationError{}
+} = ResetTOTPRequestValidationError{}
 
-// Validate checks the field values on ListAliasesResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ListAliasesResponse) Validate() error {
+// Validate checks the field values on ResetTOTPResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ResetTOTPResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListAliasesResponse with the rules
+// ValidateAll checks the field values on ResetTOTPResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ListAliasesResponseMultiError, or nil if none found.
-func (m *ListAliasesResponse) ValidateAll() error {
+// ResetTOTPResponseMultiError, or nil if none found.
+func (m *ResetTOTPResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListAliasesResponse) validate(all bool) error {
+func (m *ResetTOTPResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetAliases() {
-		_, _ = idx, item
-
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, ListAliasesResponseValidationError{
-						field:  fmt.Sprintf("Aliases[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, ListAliasesResponseValidationError{
-						field:  fmt.Sprintf("Aliases[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return ListAliasesResponseValidationError{
-					field:  fmt.Sprintf("Aliases[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
-
-	}
+	// no validation rules for Success
 
 	if len(errors) > 0 {
-		return ListAliasesResponseMultiError(errors)
+		return ResetTOTPResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListAliasesResponseMultiError is an error wrapping multiple validation
-// errors returned by ListAliasesResponse.ValidateAll() if the designated
-// constraints aren't met.
-type ListAliasesResponseMultiError []error
+// ResetTOTPResponseMultiError is an error wrapping multiple validation errors
+// returned by ResetTOTPResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ResetTOTPResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListAliasesResponseMultiError) Error() string {
+func (m ResetTOTPResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2413,11 +9199,11 @@ func (m ListAliasesResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListAliasesResponseMultiError) AllErrors() []error { return m }
+func (m ResetTOTPResponseMultiError) AllErrors() []error { return m }
 
-// ListAliasesResponseValidationError is the validation error returned by
-// ListAliasesResponse.Validate if the designated constraints aren't met.
-type ListAliasesResponseValidationError struct {
+// ResetTOTPResponseValidationError is the validation error returned by
+// ResetTOTPResponse.Validate if the designated constraints aren't met.
+type ResetTOTPResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2425,24 +9211,24 @@ type ListAliasesResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListAliasesResponseValidationError) Field() string { return e.field }
+func (e ResetTOTPResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListAliasesResponseValidationError) Reason() string { return e.reason }
+func (e ResetTOTPResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListAliasesResponseValidationError) Cause() error { return e.cause }
+func (e ResetTOTPResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListAliasesResponseValidationError) Key() bool { return e.key }
+func (e ResetTOTPResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListAliasesResponseValidationError) ErrorName() string {
-	return "ListAliasesResponseValidationError"
+func (e ResetTOTPResponseValidationError) ErrorName() string {
+	return "ResetTOTPResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListAliasesResponseValidationError) Error() string {
+func (e ResetTOTPResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2454,14 +9240,14 @@ func (e ListAliasesResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListAliasesResponse.%s: %s%s",
+		"invalid %sResetTOTPResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListAliasesResponseValidationError{}
+var _ error = ResetTOTPResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2469,4 +9255,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListAliasesResponseValidationError{}
+} = ResetTOTPResponseValidationError{}